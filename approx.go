@@ -0,0 +1,168 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"slices"
+)
+
+// Tolerance describes how far apart two float64 values may be and
+// still be considered equal. Two values are equal if they are exactly
+// equal or if they are within any one of the non-zero tolerances, so
+// the zero Tolerance compares exactly.
+//
+// Infinities are only equal to an infinity of the same sign.
+// NaN is never equal to anything unless NaNEqual is true,
+// in which case NaN is equal to NaN.
+type Tolerance struct {
+	// Abs is the largest allowed absolute difference.
+	Abs float64 `json:"abs"`
+	// Rel is the largest allowed difference relative
+	// to the larger magnitude of the two values.
+	Rel float64 `json:"rel"`
+	// ULP is the largest allowed number of representable
+	// float64 values between the two values.
+	ULP uint64 `json:"ulp"`
+	// NaNEqual makes NaN equal to NaN.
+	NaNEqual bool `json:"nan_equal"`
+}
+
+// DefaultTolerance is a tolerance suitable for values that
+// have been through a modest amount of arithmetic.
+var DefaultTolerance = Tolerance{Abs: 1e-12, Rel: 1e-9}
+
+// Equal will check if a and b are equal within the tolerance.
+func (t Tolerance) Equal(a, b float64) bool {
+	if a == b {
+		return true
+	}
+
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return t.NaNEqual && math.IsNaN(a) && math.IsNaN(b)
+	}
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return false
+	}
+
+	diff := math.Abs(a - b)
+	if diff <= t.Abs {
+		return true
+	}
+	if diff <= t.Rel*math.Max(math.Abs(a), math.Abs(b)) {
+		return true
+	}
+
+	return t.ULP > 0 && ulpDistance(a, b) <= t.ULP
+}
+
+// EqualApprox will check if two SliceFloat64s are the same length
+// and have values in the same positions that are equal within the tolerance.
+func (s SliceFloat64) EqualApprox(s2 SliceFloat64, tol Tolerance) bool {
+	if len(s) != len(s2) {
+		return false
+	}
+
+	for i, v := range s {
+		if !tol.Equal(v, s2[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SimilarToApprox will check if two SliceFloat64s are the same length
+// and, once both are sorted, have values that are equal within the
+// tolerance in the same positions.
+func (s SliceFloat64) SimilarToApprox(s2 SliceFloat64, tol Tolerance) bool {
+	if len(s) != len(s2) {
+		return false
+	}
+
+	a, b := s.Copy(), s2.Copy()
+	slices.SortFunc(a, compareNaNLast)
+	slices.SortFunc(b, compareNaNLast)
+
+	return a.EqualApprox(b, tol)
+}
+
+// ContainsApprox will check if the slice contains a value
+// that is equal to f within the tolerance.
+func (s SliceFloat64) ContainsApprox(f float64, tol Tolerance) bool {
+	for _, v := range s {
+		if tol.Equal(v, f) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EqualToApprox will return the indices, total count, and percentage of slice of the float64 numbers
+// which are equal to the supplied float64 argument within the tolerance.
+func (s SliceFloat64) EqualToApprox(value float64, tol Tolerance) (indices []int, count int, percentage float64) {
+	for i, v := range s {
+		if tol.Equal(v, value) {
+			indices = append(indices, i)
+			count++
+		}
+	}
+
+	percentage = float64(count) / float64(len(s))
+
+	return
+}
+
+// ulpDistance will return the number of representable
+// float64 values between two finite values.
+func ulpDistance(a, b float64) uint64 {
+	x, y := orderedBits(a), orderedBits(b)
+	if x < y {
+		x, y = y, x
+	}
+
+	return uint64(x) - uint64(y)
+}
+
+// orderedBits will map a float64 to an int64 that has the same
+// ordering, with -0 and 0 both mapping to 0.
+func orderedBits(f float64) int64 {
+	b := int64(math.Float64bits(f))
+	if b < 0 {
+		return math.MinInt64 - b
+	}
+
+	return b
+}
+
+// compareNaNLast will order float64 values in ascending
+// order with NaN after every other value.
+func compareNaNLast(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return 1
+	case math.IsNaN(b):
+		return -1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
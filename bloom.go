@@ -0,0 +1,209 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+var (
+	ErrFilterEncoding = errors.New("invalid filter encoding")
+)
+
+const bloomVersion = 1
+
+// DefaultFilterCapacity and DefaultBloomFilterFalsePositiveRate size
+// the zero BloomFilter and CuckooFilter on their first addition, and
+// replace invalid values supplied to NewBloomFilter.
+const (
+	DefaultFilterCapacity               = 1000
+	DefaultBloomFilterFalsePositiveRate = 0.01
+)
+
+// BloomFilter is a probabilistic set that answers membership
+// checks in constant time. It never reports a false negative,
+// and reports a false positive at roughly the rate it was sized for.
+// Values cannot be removed; use a CuckooFilter for that.
+//
+// The zero BloomFilter is empty and ready to use, sized for
+// DefaultFilterCapacity values at DefaultBloomFilterFalsePositiveRate.
+type BloomFilter struct {
+	bits   []uint64
+	size   uint64
+	hashes uint64
+}
+
+// NewBloomFilter will return an empty BloomFilter sized to hold n
+// values with the supplied false positive rate. Values of n less
+// than 1 are treated as 1, and a false positive rate outside of (0, 1)
+// is replaced by DefaultBloomFilterFalsePositiveRate.
+func NewBloomFilter(n int, falsePositiveRate float64) *BloomFilter {
+	if n < 1 {
+		n = 1
+	}
+	if !(falsePositiveRate > 0 && falsePositiveRate < 1) {
+		falsePositiveRate = DefaultBloomFilterFalsePositiveRate
+	}
+
+	size := math.Ceil(-float64(n) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	hashes := math.Max(1, math.Round(size/float64(n)*math.Ln2))
+
+	return &BloomFilter{
+		bits:   make([]uint64, (uint64(size)+63)/64),
+		size:   uint64(size),
+		hashes: uint64(hashes),
+	}
+}
+
+// BloomFilter will return a BloomFilter containing every
+// value of the slice with the supplied false positive rate.
+func (s SliceString) BloomFilter(falsePositiveRate float64) *BloomFilter {
+	b := NewBloomFilter(len(s), falsePositiveRate)
+	for _, v := range s {
+		b.Add(v)
+	}
+
+	return b
+}
+
+// BloomFilter will return a BloomFilter containing every
+// value of the slice with the supplied false positive rate.
+func (s SliceInt) BloomFilter(falsePositiveRate float64) *BloomFilter {
+	b := NewBloomFilter(len(s), falsePositiveRate)
+	for _, v := range s {
+		b.AddInt(v)
+	}
+
+	return b
+}
+
+// BloomFilter will return a BloomFilter containing every
+// value of the slice with the supplied false positive rate.
+func (s SliceFloat64) BloomFilter(falsePositiveRate float64) *BloomFilter {
+	b := NewBloomFilter(len(s), falsePositiveRate)
+	for _, v := range s {
+		b.AddFloat64(v)
+	}
+
+	return b
+}
+
+// Add will add a string to the filter.
+func (b *BloomFilter) Add(value string) {
+	b.addHash(hashString(value, 0))
+}
+
+// AddInt will add an integer to the filter.
+func (b *BloomFilter) AddInt(value int) {
+	b.addHash(hashInt(value))
+}
+
+// AddFloat64 will add a float64 to the filter.
+func (b *BloomFilter) AddFloat64(value float64) {
+	b.addHash(hashFloat64(value))
+}
+
+// Contains will return false if the string was definitely
+// not added, and true if it probably was.
+func (b *BloomFilter) Contains(value string) bool {
+	return b.containsHash(hashString(value, 0))
+}
+
+// ContainsInt will return false if the integer was definitely
+// not added, and true if it probably was.
+func (b *BloomFilter) ContainsInt(value int) bool {
+	return b.containsHash(hashInt(value))
+}
+
+// ContainsFloat64 will return false if the float64 was definitely
+// not added, and true if it probably was.
+func (b *BloomFilter) ContainsFloat64(value float64) bool {
+	return b.containsHash(hashFloat64(value))
+}
+
+// MarshalBinary will encode the filter into a stable
+// big-endian binary format.
+func (b *BloomFilter) MarshalBinary() ([]byte, error) {
+	b.init()
+	data := make([]byte, 0, 17+8*len(b.bits))
+	data = append(data, bloomVersion)
+	data = binary.BigEndian.AppendUint64(data, b.size)
+	data = binary.BigEndian.AppendUint64(data, b.hashes)
+	for _, word := range b.bits {
+		data = binary.BigEndian.AppendUint64(data, word)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary will decode a filter encoded by MarshalBinary,
+// replacing the contents of this filter.
+func (b *BloomFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 17 || data[0] != bloomVersion {
+		return ErrFilterEncoding
+	}
+
+	size := binary.BigEndian.Uint64(data[1:])
+	hashes := binary.BigEndian.Uint64(data[9:])
+	words := (size + 63) / 64
+	if size == 0 || hashes == 0 || uint64(len(data)-17) != 8*words {
+		return ErrFilterEncoding
+	}
+
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.BigEndian.Uint64(data[17+8*i:])
+	}
+
+	b.bits, b.size, b.hashes = bits, size, hashes
+	return nil
+}
+
+// init will size the zero BloomFilter with the defaults.
+func (b *BloomFilter) init() {
+	if b.size == 0 {
+		*b = *NewBloomFilter(DefaultFilterCapacity, DefaultBloomFilterFalsePositiveRate)
+	}
+}
+
+// addHash will set every bit of the hash.
+func (b *BloomFilter) addHash(h uint64) {
+	b.init()
+
+	h1, h2 := h, mix64(h)|1
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		b.bits[bit/64] |= 1 << (bit % 64)
+	}
+}
+
+// containsHash will check if every bit of the hash is set.
+func (b *BloomFilter) containsHash(h uint64) bool {
+	if b.size == 0 {
+		return false
+	}
+
+	h1, h2 := h, mix64(h)|1
+	for i := uint64(0); i < b.hashes; i++ {
+		bit := (h1 + i*h2) % b.size
+		if b.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,230 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+// The methods ending in E are checked versions of the methods
+// of the same name. Instead of panicking or returning a
+// placeholder value for bad input, they return ErrEmpty for
+// empty input or ErrBounds for out of range indices. Methods
+// that pair up the values of two slices, such as Pearson,
+// return ErrLengthMismatch.
+
+// GetE will return the value at the index.
+func (s SliceFloat64) GetE(index int) (float64, error) {
+	return getE(s, index)
+}
+
+// SetE will set the value at the index.
+func (s SliceFloat64) SetE(index int, value float64) error {
+	return setE(s, index, value)
+}
+
+// SubsliceE will return the values from start up to end.
+func (s SliceFloat64) SubsliceE(start, end int) (SliceFloat64, error) {
+	return subsliceE(s, start, end)
+}
+
+// AvgE will return the mean of the values in the slice.
+// A slice of only NaN values is treated as empty.
+func (s SliceFloat64) AvgE() (float64, error) {
+	if len(s) == s.NaNCount() {
+		return 0, ErrEmpty
+	}
+
+	return s.Avg(), nil
+}
+
+// MinE will return the smallest non-NaN value in the slice.
+func (s SliceFloat64) MinE() (float64, error) {
+	if len(s) == s.NaNCount() {
+		return 0, ErrEmpty
+	}
+
+	return s.Min(), nil
+}
+
+// MaxE will return the largest non-NaN value in the slice.
+func (s SliceFloat64) MaxE() (float64, error) {
+	if len(s) == s.NaNCount() {
+		return 0, ErrEmpty
+	}
+
+	return s.Max(), nil
+}
+
+// MedianE will return the median of the non-NaN values in the slice.
+func (s SliceFloat64) MedianE() (float64, error) {
+	if len(s) == s.NaNCount() {
+		return 0, ErrEmpty
+	}
+
+	return s.MedianValue(), nil
+}
+
+// GetE will return the value at the index.
+func (s SliceInt) GetE(index int) (int, error) {
+	return getE(s, index)
+}
+
+// SetE will set the value at the index.
+func (s SliceInt) SetE(index int, value int) error {
+	return setE(s, index, value)
+}
+
+// SubsliceE will return the values from start up to end.
+func (s SliceInt) SubsliceE(start, end int) (SliceInt, error) {
+	return subsliceE(s, start, end)
+}
+
+// MinE will return the index and the value of
+// the smallest value in the slice.
+func (s SliceInt) MinE() (index, value int, err error) {
+	if len(s) == 0 {
+		return -1, 0, ErrEmpty
+	}
+
+	index, value = s.Min()
+	return
+}
+
+// MaxE will return the index of the
+// largest value in the slice.
+func (s SliceInt) MaxE() (index int, err error) {
+	if len(s) == 0 {
+		return -1, ErrEmpty
+	}
+
+	return s.Max(), nil
+}
+
+// GetE will return the value at the index.
+func (s SliceInt64) GetE(index int) (int64, error) {
+	return getE(s, index)
+}
+
+// SetE will set the value at the index.
+func (s SliceInt64) SetE(index int, value int64) error {
+	return setE(s, index, value)
+}
+
+// SubsliceE will return the values from start up to end.
+func (s SliceInt64) SubsliceE(start, end int) (SliceInt64, error) {
+	return subsliceE(s, start, end)
+}
+
+// MinE will return the index and the value of
+// the smallest value in the slice.
+func (s SliceInt64) MinE() (index int, value int64, err error) {
+	if len(s) == 0 {
+		return -1, 0, ErrEmpty
+	}
+
+	index, value = s.Min()
+	return
+}
+
+// MaxE will return the index of the
+// largest value in the slice.
+func (s SliceInt64) MaxE() (index int, err error) {
+	if len(s) == 0 {
+		return -1, ErrEmpty
+	}
+
+	return s.Max(), nil
+}
+
+// GetE will return the value at the index.
+func (s SliceString) GetE(index int) (string, error) {
+	return getE(s, index)
+}
+
+// SetE will set the value at the index.
+func (s SliceString) SetE(index int, value string) error {
+	return setE(s, index, value)
+}
+
+// SubsliceE will return the values from start up to end.
+func (s SliceString) SubsliceE(start, end int) (SliceString, error) {
+	return subsliceE(s, start, end)
+}
+
+// GetE will return the value at the index.
+func (s SliceBool) GetE(index int) (bool, error) {
+	return getE(s, index)
+}
+
+// SetE will set the value at the index.
+func (s SliceBool) SetE(index int, value bool) error {
+	return setE(s, index, value)
+}
+
+// SubsliceE will return the values from start up to end.
+func (s SliceBool) SubsliceE(start, end int) (SliceBool, error) {
+	return subsliceE(s, start, end)
+}
+
+// TruePercentageE will return the percentage
+// of values that are true.
+func (s SliceBool) TruePercentageE() (float64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+
+	return s.TruePercentage(), nil
+}
+
+// FalsePercentageE will return the percentage
+// of values that are false.
+func (s SliceBool) FalsePercentageE() (float64, error) {
+	if len(s) == 0 {
+		return 0, ErrEmpty
+	}
+
+	return s.FalsePercentage(), nil
+}
+
+// AverageCountE will return the average integer value.
+func (m MapFloat64Int) AverageCountE() (int, error) {
+	if len(m) == 0 {
+		return 0, ErrEmpty
+	}
+
+	return m.AverageCount(), nil
+}
+
+func getE[S ~[]E, E any](s S, index int) (value E, err error) {
+	if index < 0 || index >= len(s) {
+		return value, ErrBounds
+	}
+
+	return s[index], nil
+}
+
+func setE[S ~[]E, E any](s S, index int, value E) error {
+	if index < 0 || index >= len(s) {
+		return ErrBounds
+	}
+
+	s[index] = value
+	return nil
+}
+
+func subsliceE[S ~[]E, E any](s S, start, end int) (S, error) {
+	if start < 0 || end < start || end > len(s) {
+		return nil, ErrBounds
+	}
+
+	return s[start:end], nil
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCheckedFloat64Empty(t *testing.T) {
+	nan := math.NaN()
+	for _, s := range []SliceFloat64{nil, {}, {nan}, {nan, nan}} {
+		for name, statistic := range map[string]func() (float64, error){
+			"AvgE":    s.AvgE,
+			"MinE":    s.MinE,
+			"MaxE":    s.MaxE,
+			"MedianE": s.MedianE,
+		} {
+			if _, err := statistic(); err != ErrEmpty {
+				t.Errorf("%s() of %v returned %v, want ErrEmpty", name, s, err)
+			}
+		}
+	}
+
+	s := SliceFloat64{nan, 2, 1}
+	if v, err := s.MinE(); err != nil || v != 1 {
+		t.Errorf("MinE() = %v, %v, want 1, nil", v, err)
+	}
+	if v, err := s.MaxE(); err != nil || v != 2 {
+		t.Errorf("MaxE() = %v, %v, want 2, nil", v, err)
+	}
+}
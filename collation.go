@@ -0,0 +1,222 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Collation is an ordering of strings. It returns a negative
+// number if a comes before b, a positive number if a comes
+// after b, and 0 if they are equivalent.
+//
+// CollateDefault, CollateBytewise, CollateCaseInsensitive,
+// CollateNatural and CollateAccentInsensitive can all be
+// used as a Collation, and none of them allocate.
+type Collation func(a, b string) int
+
+// CollateDefault is the order of SliceString.Less. Strings are
+// compared rune by rune ignoring case, except that the first rune
+// that only differs by case decides the order, with upper case first.
+// A string comes after every string it starts with.
+func CollateDefault(a, b string) int {
+	return collateRunes(a, b, compareRuneDefault)
+}
+
+// CollateBytewise compares the bytes of the strings,
+// which orders valid UTF-8 strings by code point.
+func CollateBytewise(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+// CollateCaseInsensitive compares strings rune by rune ignoring
+// case, so strings that only differ by case are equivalent.
+func CollateCaseInsensitive(a, b string) int {
+	return collateRunes(a, b, func(ra, rb rune) int {
+		return cmp.Compare(unicode.ToLower(ra), unicode.ToLower(rb))
+	})
+}
+
+// CollateAccentInsensitive compares strings like CollateCaseInsensitive
+// after removing the accents of Latin letters, so "Café" and "cafe"
+// are equivalent.
+func CollateAccentInsensitive(a, b string) int {
+	return collateRunes(a, b, func(ra, rb rune) int {
+		return cmp.Compare(unicode.ToLower(unaccent(ra)), unicode.ToLower(unaccent(rb)))
+	})
+}
+
+// CollateNatural compares runs of ASCII digits by their numeric
+// value, so "file2" comes before "file10", and everything else like
+// CollateDefault. Numbers that are only different by leading zeros
+// are ordered with fewer zeros first if the strings are otherwise
+// equivalent.
+func CollateNatural(a, b string) int {
+	var zeros int
+	for len(a) > 0 && len(b) > 0 {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			na, nb := digits(a), digits(b)
+			ta, tb := strings.TrimLeft(a[:na], "0"), strings.TrimLeft(b[:nb], "0")
+			if c := cmp.Compare(len(ta), len(tb)); c != 0 {
+				return c
+			}
+			if c := strings.Compare(ta, tb); c != 0 {
+				return c
+			}
+			if zeros == 0 {
+				zeros = cmp.Compare(na, nb)
+			}
+			a, b = a[na:], b[nb:]
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if c := compareRuneDefault(ra, rb); c != 0 {
+			return c
+		}
+		a, b = a[sa:], b[sb:]
+	}
+
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+
+	return zeros
+}
+
+// SortedBy will return a copy of the slice sorted by the collation.
+func (s SliceString) SortedBy(collation Collation) SliceString {
+	sorted := s.Copy()
+	sorted.SortInPlaceBy(collation)
+	return sorted
+}
+
+// SortInPlaceBy will stably sort the slice itself by the collation.
+func (s SliceString) SortInPlaceBy(collation Collation) {
+	slices.SortStableFunc(s, collation)
+}
+
+// SortedStringBy will sort a copy of the strings in the slice by the
+// collation and return them as a single string delimited by the
+// supplied delimiter argument.
+func (s SliceString) SortedStringBy(delimiter string, collation Collation) string {
+	return strings.Join(s.SortedBy(collation), delimiter)
+}
+
+// Search will binary search a slice sorted by SortInPlace for the
+// target, returning the index it is at, or would be inserted at,
+// and whether it was found.
+func (s SliceString) Search(target string) (int, bool) {
+	return s.SearchBy(target, CollateDefault)
+}
+
+// SearchBy will binary search a slice sorted by the collation for the
+// target, returning the index of the first equivalent string, or where
+// the target would be inserted, and whether it was found.
+func (s SliceString) SearchBy(target string, collation Collation) (int, bool) {
+	return slices.BinarySearchFunc(s, target, collation)
+}
+
+// ArgsortBy will return the permutation that
+// stably sorts the slice by the collation.
+func (s SliceString) ArgsortBy(ascending bool, collation Collation) Permutation {
+	return argsort(len(s), ascending, func(i, j int) bool {
+		return collation(s[i], s[j]) < 0
+	}, nil)
+}
+
+// collateRunes will compare two strings rune by rune,
+// with a string coming after every string it starts with.
+func collateRunes(a, b string, compare func(ra, rb rune) int) int {
+	for len(a) > 0 && len(b) > 0 {
+		// compare any common ASCII prefix byte by byte.
+		if a[0] == b[0] && a[0] < utf8.RuneSelf {
+			a, b = a[1:], b[1:]
+			continue
+		}
+
+		ra, sa := utf8.DecodeRuneInString(a)
+		rb, sb := utf8.DecodeRuneInString(b)
+		if c := compare(ra, rb); c != 0 {
+			return c
+		}
+		a, b = a[sa:], b[sb:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// compareRuneDefault will order runes by their lower case,
+// and then by the runes themselves.
+func compareRuneDefault(ra, rb rune) int {
+	if ra == rb {
+		return 0
+	}
+	if c := cmp.Compare(unicode.ToLower(ra), unicode.ToLower(rb)); c != 0 {
+		return c
+	}
+
+	return cmp.Compare(ra, rb)
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// digits will return the length of the run of
+// ASCII digits at the start of the string.
+func digits(s string) int {
+	n := 0
+	for n < len(s) && isDigit(s[n]) {
+		n++
+	}
+
+	return n
+}
+
+// unaccents maps accented Latin letters to their base letter.
+var unaccents = func() map[rune]rune {
+	accented := []rune("ÀÁÂÃÄÅÇÈÉÊËÌÍÎÏÑÒÓÔÕÖØÙÚÛÜÝàáâãäåçèéêëìíîïñòóôõöøùúûüýÿ" +
+		"ĀāĂăĄąĆćĈĉĊċČčĎďĐđĒēĔĕĖėĘęĚěĜĝĞğĠġĢģĤĥĦħĨĩĪīĬĭĮįİıĴĵĶķĹĺĻļĽľĿŀŁł" +
+		"ŃńŅņŇňŌōŎŏŐőŔŕŖŗŘřŚśŜŝŞşŠšŢţŤťŦŧŨũŪūŬŭŮůŰűŲųŴŵŶŷŸŹźŻżŽž")
+	base := []rune("AAAAAACEEEEIIIINOOOOOOUUUUYaaaaaaceeeeiiiinoooooouuuuyy" +
+		"AaAaAaCcCcCcCcDdDdEeEeEeEeEeGgGgGgGgHhHhIiIiIiIiIiJjKkLlLlLlLlLl" +
+		"NnNnNnOoOoOoRrRrRrSsSsSsSsTtTtTtUuUuUuUuUuUuWwYyYZzZzZz")
+
+	m := make(map[rune]rune, len(accented))
+	for i, r := range accented {
+		m[r] = base[i]
+	}
+
+	return m
+}()
+
+// unaccent will return the base letter of an accented
+// Latin letter, and any other rune unchanged.
+func unaccent(r rune) rune {
+	if r < 0xC0 {
+		return r
+	}
+	if b, ok := unaccents[r]; ok {
+		return b
+	}
+
+	return r
+}
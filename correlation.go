@@ -0,0 +1,336 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"sort"
+)
+
+// CorrelationMethod selects the correlation coefficient
+// used by CorrelationMatrix.
+type CorrelationMethod int
+
+const (
+	// Pearson is the linear correlation coefficient.
+	Pearson CorrelationMethod = iota
+	// Spearman is the Pearson correlation of the ranks.
+	Spearman
+	// Kendall is the Kendall tau-b rank correlation.
+	Kendall
+)
+
+// Covariance will return the population covariance between the
+// slice and another slice of the same length.
+// Positions where either value is NaN are ignored.
+func (s SliceFloat64) Covariance(other SliceFloat64) (float64, error) {
+	x, y, err := pairwiseComplete(s, other)
+	if err != nil || len(x) == 0 {
+		return 0, err
+	}
+
+	return covariance(x, y) / float64(len(x)), nil
+}
+
+// SampleCovariance will return the (Bessel corrected) sample covariance
+// between the slice and another slice of the same length.
+// Positions where either value is NaN are ignored.
+func (s SliceFloat64) SampleCovariance(other SliceFloat64) (float64, error) {
+	x, y, err := pairwiseComplete(s, other)
+	if err != nil || len(x) < 2 {
+		return 0, err
+	}
+
+	return covariance(x, y) / float64(len(x)-1), nil
+}
+
+// Pearson will return the Pearson correlation coefficient between
+// the slice and another slice of the same length.
+// Positions where either value is NaN are ignored. The result is
+// NaN if either slice has no variance.
+func (s SliceFloat64) Pearson(other SliceFloat64) (float64, error) {
+	x, y, err := pairwiseComplete(s, other)
+	if err != nil {
+		return 0, err
+	}
+
+	return pearson(x, y), nil
+}
+
+// Spearman will return the Spearman rank correlation coefficient
+// between the slice and another slice of the same length. Tied
+// values are given their average rank.
+// Positions where either value is NaN are ignored.
+func (s SliceFloat64) Spearman(other SliceFloat64) (float64, error) {
+	x, y, err := pairwiseComplete(s, other)
+	if err != nil {
+		return 0, err
+	}
+
+	return pearson(x.Ranks(), y.Ranks()), nil
+}
+
+// Kendall will return the Kendall tau-b rank correlation coefficient
+// between the slice and another slice of the same length, which
+// accounts for ties in either slice. It runs in O(n log n).
+// Positions where either value is NaN are ignored.
+func (s SliceFloat64) Kendall(other SliceFloat64) (float64, error) {
+	x, y, err := pairwiseComplete(s, other)
+	if err != nil {
+		return 0, err
+	}
+
+	return kendall(x, y), nil
+}
+
+// Correlation will return the correlation coefficient between the
+// slice and another slice of the same length using the supplied method.
+func (s SliceFloat64) Correlation(other SliceFloat64, method CorrelationMethod) (float64, error) {
+	switch method {
+	case Spearman:
+		return s.Spearman(other)
+	case Kendall:
+		return s.Kendall(other)
+	default:
+		return s.Pearson(other)
+	}
+}
+
+// Ranks will return the 1-based rank of every value in the slice.
+// Tied values are given the average of the ranks they span.
+func (s SliceFloat64) Ranks() SliceFloat64 {
+	indices := make([]int, len(s))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return s[indices[a]] < s[indices[b]]
+	})
+
+	ranks := make(SliceFloat64, len(s))
+	for start := 0; start < len(indices); {
+		end := start + 1
+		for end < len(indices) && s[indices[end]] == s[indices[start]] {
+			end++
+		}
+
+		// positions start..end-1 have ranks start+1..end
+		rank := float64(start+1+end) / 2
+		for _, i := range indices[start:end] {
+			ranks[i] = rank
+		}
+		start = end
+	}
+
+	return ranks
+}
+
+// CorrelationMatrix holds the pairwise correlation
+// coefficients of a set of named columns.
+type CorrelationMatrix struct {
+	// Names are the column names in sorted order.
+	Names SliceString `json:"names"`
+	// Values[i][j] is the correlation between Names[i] and Names[j].
+	Values [][]float64 `json:"values"`
+}
+
+// NewCorrelationMatrix will return the matrix of correlation coefficients
+// between every pair of the supplied columns. All columns must have
+// the same length.
+func NewCorrelationMatrix(columns map[string]SliceFloat64, method CorrelationMethod) (*CorrelationMatrix, error) {
+	names := SliceString(Keys(columns))
+	sort.Strings(names)
+
+	values := make([][]float64, len(names))
+	for i := range values {
+		values[i] = make([]float64, len(names))
+	}
+
+	for i, a := range names {
+		for j := i; j < len(names); j++ {
+			c, err := columns[a].Correlation(columns[names[j]], method)
+			if err != nil {
+				return nil, err
+			}
+			values[i][j] = c
+			values[j][i] = c
+		}
+	}
+
+	return &CorrelationMatrix{
+		Names:  names,
+		Values: values,
+	}, nil
+}
+
+// Get will return the correlation between two named columns,
+// and whether both columns exist in the matrix.
+func (c *CorrelationMatrix) Get(a, b string) (float64, bool) {
+	i, j := Index(c.Names, a), Index(c.Names, b)
+	if i < 0 || j < 0 {
+		return 0, false
+	}
+
+	return c.Values[i][j], true
+}
+
+// pairwiseComplete will return copies of both slices with every
+// position where either slice has a NaN removed.
+func pairwiseComplete(a, b SliceFloat64) (x, y SliceFloat64, err error) {
+	if len(a) != len(b) {
+		return nil, nil, ErrLengthMismatch
+	}
+
+	x = make(SliceFloat64, 0, len(a))
+	y = make(SliceFloat64, 0, len(b))
+	for i := range a {
+		if math.IsNaN(a[i]) || math.IsNaN(b[i]) {
+			continue
+		}
+		x = append(x, a[i])
+		y = append(y, b[i])
+	}
+
+	return x, y, nil
+}
+
+// covariance will return the sum of the co-deviations
+// of two aligned slices from their means.
+func covariance(x, y SliceFloat64) (sum float64) {
+	meanX, meanY := x.Avg(), y.Avg()
+	for i := range x {
+		sum += (x[i] - meanX) * (y[i] - meanY)
+	}
+
+	return
+}
+
+// pearson will return the Pearson correlation of two aligned slices.
+func pearson(x, y SliceFloat64) float64 {
+	if len(x) < 2 {
+		return math.NaN()
+	}
+
+	denominator := math.Sqrt(x.Variance() * y.Variance())
+	if denominator == 0 {
+		return math.NaN()
+	}
+
+	return covariance(x, y) / float64(len(x)) / denominator
+}
+
+// kendall will return the Kendall tau-b correlation of two aligned
+// slices using Knight's O(n log n) algorithm.
+func kendall(x, y SliceFloat64) float64 {
+	n := len(x)
+	if n < 2 {
+		return math.NaN()
+	}
+
+	indices := make([]int, n)
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.Slice(indices, func(a, b int) bool {
+		i, j := indices[a], indices[b]
+		if x[i] != x[j] {
+			return x[i] < x[j]
+		}
+		return y[i] < y[j]
+	})
+
+	// ties in x, and joint ties in both x and y
+	var xTies, jointTies int
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && x[indices[end]] == x[indices[start]] {
+			end++
+		}
+		xTies += pairs(end - start)
+
+		for jStart := start; jStart < end; {
+			jEnd := jStart + 1
+			for jEnd < end && y[indices[jEnd]] == y[indices[jStart]] {
+				jEnd++
+			}
+			jointTies += pairs(jEnd - jStart)
+			jStart = jEnd
+		}
+		start = end
+	}
+
+	// the number of swaps a stable sort by y needs
+	// is the number of discordant pairs.
+	ys := make(SliceFloat64, n)
+	for i, idx := range indices {
+		ys[i] = y[idx]
+	}
+	swaps := mergeCount(ys, make(SliceFloat64, n))
+
+	var yTies int
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && ys[end] == ys[start] {
+			end++
+		}
+		yTies += pairs(end - start)
+		start = end
+	}
+
+	total := pairs(n)
+	denominator := math.Sqrt(float64(total-xTies) * float64(total-yTies))
+	if denominator == 0 {
+		return math.NaN()
+	}
+
+	numerator := total - xTies - yTies + jointTies - 2*swaps
+	return float64(numerator) / denominator
+}
+
+// pairs will return the number of unordered pairs in n items.
+func pairs(n int) int {
+	return n * (n - 1) / 2
+}
+
+// mergeCount will stably sort s using buf as scratch space and
+// return the number of inversions (strictly greater values
+// preceding smaller ones) that were removed.
+func mergeCount(s, buf SliceFloat64) (swaps int) {
+	if len(s) < 2 {
+		return 0
+	}
+
+	mid := len(s) / 2
+	swaps += mergeCount(s[:mid], buf[:mid])
+	swaps += mergeCount(s[mid:], buf[mid:])
+
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(s) {
+		if s[j] < s[i] {
+			buf[k] = s[j]
+			swaps += mid - i
+			j++
+		} else {
+			buf[k] = s[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], s[i:mid])
+	copy(buf[k:], s[j:])
+	copy(s, buf)
+
+	return swaps
+}
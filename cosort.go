@@ -0,0 +1,250 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"sort"
+)
+
+var (
+	ErrUnorderedColumn = errors.New("column values cannot be ordered")
+)
+
+// SortKey is a column to order rows by when co-sorting.
+// The zero SortKey orders column 0 ascending with nulls last.
+//
+// Null values are NaN, the empty string and nil. They are
+// placed first or last whichever the direction.
+type SortKey struct {
+	// Column is the index of the key column.
+	Column     int
+	Descending bool
+	NullsFirst bool
+	// Collation orders a SliceString column,
+	// CollateDefault if it is nil.
+	Collation Collation
+}
+
+// CoSort will stably reorder every column together by the keys, the
+// first key deciding the order and each following key breaking ties
+// left by the keys before it. Rows that are equal by every key keep
+// their order. It generalizes SortStringsByFloat64 and friends:
+//
+//	err := CoSort([]Slice{names, scores}, SortKey{Column: 1, Descending: true})
+//
+// Key columns of other Slice types, such as NumberSlice, are compared
+// through the values returned by Get, which must all be of the same
+// integer, floating point, string or boolean type (or nil), otherwise
+// ErrUnorderedColumn is returned. ErrLengthMismatch is returned if the
+// columns are not all the same length, and ErrBounds if a key refers to
+// a column that does not exist. Nothing is reordered if there is an error.
+func CoSort(columns []Slice, keys ...SortKey) error {
+	p, err := CoSortPermutation(columns, keys...)
+	if err != nil {
+		return err
+	}
+
+	return p.Apply(columns...)
+}
+
+// CoSortPermutation will return the permutation that CoSort would
+// apply to the columns, without reordering them.
+func CoSortPermutation(columns []Slice, keys ...SortKey) (Permutation, error) {
+	if len(columns) == 0 {
+		return Permutation{}, nil
+	}
+
+	n := columns[0].Len()
+	for _, column := range columns {
+		if column.Len() != n {
+			return nil, ErrLengthMismatch
+		}
+	}
+
+	comparers := make([]func(i, j int) int, len(keys))
+	for k, key := range keys {
+		if key.Column < 0 || key.Column >= len(columns) {
+			return nil, ErrBounds
+		}
+		compare, err := keyComparer(columns[key.Column], key)
+		if err != nil {
+			return nil, err
+		}
+		comparers[k] = compare
+	}
+
+	p := NewPermutation(n)
+	sort.SliceStable(p, func(a, b int) bool {
+		for _, compare := range comparers {
+			if c := compare(p[a], p[b]); c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	return p, nil
+}
+
+// keyComparer will return a function comparing two rows of
+// the column in the direction and null placement of the key.
+func keyComparer(column Slice, key SortKey) (func(i, j int) int, error) {
+	compare, null, err := columnComparer(column, key.Collation)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(i, j int) int {
+		iNull, jNull := null(i), null(j)
+		switch {
+		case iNull && jNull:
+			return 0
+		case iNull != jNull:
+			if iNull == key.NullsFirst {
+				return -1
+			}
+			return 1
+		case key.Descending:
+			return compare(j, i)
+		}
+		return compare(i, j)
+	}, nil
+}
+
+// columnComparer will return functions comparing two rows of the
+// column in ascending order and reporting if a row is null.
+// Strings are compared by the collation, if it is not nil.
+func columnComparer(column Slice, collation Collation) (compare func(i, j int) int, null func(i int) bool, err error) {
+	null = func(int) bool {
+		return false
+	}
+
+	switch s := column.(type) {
+	case SliceFloat64:
+		compare = func(i, j int) int {
+			return cmp.Compare(s[i], s[j])
+		}
+		null = func(i int) bool {
+			return isNaN(s[i])
+		}
+	case SliceInt:
+		compare = func(i, j int) int {
+			return cmp.Compare(s[i], s[j])
+		}
+	case SliceInt64:
+		compare = func(i, j int) int {
+			return cmp.Compare(s[i], s[j])
+		}
+	case SliceString:
+		if collation == nil {
+			collation = CollateDefault
+		}
+		compare = func(i, j int) int {
+			return collation(s[i], s[j])
+		}
+		null = func(i int) bool {
+			return s[i] == ""
+		}
+	case SliceBool:
+		compare = func(i, j int) int {
+			return compareBool(s[i], s[j])
+		}
+	default:
+		return valueComparer(column, collation)
+	}
+
+	return compare, null, nil
+}
+
+// valueComparer will return functions like columnComparer for any
+// column, by comparing the values returned by its Get method.
+func valueComparer(column Slice, collation Collation) (compare func(i, j int) int, null func(i int) bool, err error) {
+	values := make([]reflect.Value, column.Len())
+	var typ reflect.Type
+	for i := range values {
+		v := column.Get(i)
+		if v == nil {
+			continue
+		}
+
+		values[i] = reflect.ValueOf(v)
+		if typ == nil {
+			typ = values[i].Type()
+		}
+		if values[i].Type() != typ {
+			return nil, nil, ErrUnorderedColumn
+		}
+	}
+
+	null = func(i int) bool {
+		return !values[i].IsValid()
+	}
+	if typ == nil {
+		return func(i, j int) int {
+			return 0
+		}, null, nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(i, j int) int {
+			return cmp.Compare(values[i].Int(), values[j].Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(i, j int) int {
+			return cmp.Compare(values[i].Uint(), values[j].Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		compare = func(i, j int) int {
+			return cmp.Compare(values[i].Float(), values[j].Float())
+		}
+		null = func(i int) bool {
+			return !values[i].IsValid() || isNaN(values[i].Float())
+		}
+	case reflect.String:
+		if collation == nil {
+			collation = CollateDefault
+		}
+		compare = func(i, j int) int {
+			return collation(values[i].String(), values[j].String())
+		}
+		null = func(i int) bool {
+			return !values[i].IsValid() || values[i].String() == ""
+		}
+	case reflect.Bool:
+		compare = func(i, j int) int {
+			return compareBool(values[i].Bool(), values[j].Bool())
+		}
+	default:
+		return nil, nil, ErrUnorderedColumn
+	}
+
+	return compare, null, nil
+}
+
+// compareBool will order false before true.
+func compareBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	}
+
+	return -1
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCoSort(t *testing.T) {
+	names := SliceString{"a", "b", "c", "d", "e", "f"}
+	groups := SliceString{"x", "y", "x", "", "y", "x"}
+	scores := SliceFloat64{1, math.NaN(), 3, 2, 5, 3}
+
+	err := CoSort([]Slice{names, groups, scores},
+		SortKey{Column: 1},
+		SortKey{Column: 2, Descending: true, NullsFirst: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := (SliceString{"c", "f", "a", "b", "e", "d"}); !names.EqualOrdered(want) {
+		t.Errorf("names = %v, want %v", names, want)
+	}
+}
+
+func TestCoSortGenericColumns(t *testing.T) {
+	values := NumberSlice[float64]{3, 1, math.NaN(), 2}
+	labels := SliceOf[string]{"c", "a", "", "b"}
+	names := SliceString{"three", "one", "nan", "two"}
+
+	if err := CoSort([]Slice{values, names}, SortKey{Column: 0}); err != nil {
+		t.Fatal(err)
+	}
+	if want := (SliceString{"one", "two", "three", "nan"}); !names.EqualOrdered(want) {
+		t.Errorf("sorted by NumberSlice, names = %v, want %v", names, want)
+	}
+
+	if err := CoSort([]Slice{labels, names}, SortKey{Column: 0, Descending: true, NullsFirst: true}); err != nil {
+		t.Fatal(err)
+	}
+	if want := (SliceOf[string]{"", "c", "b", "a"}); !labels.EqualOrdered(want) {
+		t.Errorf("sorted by SliceOf, labels = %v, want %v", labels, want)
+	}
+}
+
+func TestCoSortUnorderedColumn(t *testing.T) {
+	pairs := SliceOf[[2]int]{{2, 0}, {1, 0}}
+	names := SliceString{"b", "a"}
+
+	if err := CoSort([]Slice{pairs, names}, SortKey{Column: 0}); err != ErrUnorderedColumn {
+		t.Fatalf("CoSort by an unordered column returned %v, want ErrUnorderedColumn", err)
+	}
+	if !names.EqualOrdered(SliceString{"b", "a"}) {
+		t.Errorf("names were reordered to %v after an error", names)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrSketchDimensions = errors.New("sketches have different dimensions")
+)
+
+// CountMinSketch approximately counts the frequency of keys
+// from a huge key space in a fixed amount of memory.
+//
+// An estimate never underestimates the true count of a key and,
+// for a sketch built with NewCountMinSketchWithError(epsilon, delta),
+// overestimates it by more than epsilon*Total() with probability
+// at most delta. Conservative update only increments the counters
+// that need to grow, which lowers the overestimation further, but
+// such a sketch cannot be decremented.
+//
+// The zero CountMinSketch is empty and ready to use, sized
+// for DefaultCountMinSketchEpsilon and DefaultCountMinSketchDelta
+// without conservative update.
+type CountMinSketch struct {
+	width        int
+	depth        int
+	conservative bool
+	total        int
+	counts       []int
+}
+
+// DefaultCountMinSketchEpsilon and DefaultCountMinSketchDelta size
+// the zero CountMinSketch, and replace invalid values supplied to
+// NewCountMinSketchWithError.
+const (
+	DefaultCountMinSketchEpsilon = 0.001
+	DefaultCountMinSketchDelta   = 0.01
+)
+
+// NewCountMinSketch will return an empty sketch with depth rows
+// of width counters. Values less than 1 are treated as 1.
+func NewCountMinSketch(width, depth int, conservative bool) *CountMinSketch {
+	if width < 1 {
+		width = 1
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	return &CountMinSketch{
+		width:        width,
+		depth:        depth,
+		conservative: conservative,
+		counts:       make([]int, width*depth),
+	}
+}
+
+// NewCountMinSketchWithError will return an empty sketch sized so
+// that estimates exceed the true count by more than epsilon times
+// the total count with probability at most delta. Values of epsilon
+// or delta outside of (0, 1) are replaced by DefaultCountMinSketchEpsilon
+// and DefaultCountMinSketchDelta.
+func NewCountMinSketchWithError(epsilon, delta float64, conservative bool) *CountMinSketch {
+	if !(epsilon > 0 && epsilon < 1) {
+		epsilon = DefaultCountMinSketchEpsilon
+	}
+	if !(delta > 0 && delta < 1) {
+		delta = DefaultCountMinSketchDelta
+	}
+
+	return NewCountMinSketch(countMinSketchWidth(epsilon), countMinSketchDepth(delta), conservative)
+}
+
+// countMinSketchWidth will return the row width that bounds
+// the overestimation to epsilon times the total count.
+func countMinSketchWidth(epsilon float64) int {
+	return int(math.Ceil(math.E / epsilon))
+}
+
+// countMinSketchDepth will return the number of rows that bound
+// the probability of exceeding the overestimation to delta.
+func countMinSketchDepth(delta float64) int {
+	return int(math.Ceil(math.Log(1 / delta)))
+}
+
+// Width will return the number of counters in each row.
+func (c *CountMinSketch) Width() int {
+	c.init()
+	return c.width
+}
+
+// Depth will return the number of rows.
+func (c *CountMinSketch) Depth() int {
+	c.init()
+	return c.depth
+}
+
+// Total will return the sum of every count added.
+func (c *CountMinSketch) Total() int {
+	return c.total
+}
+
+// Increment will add 1 to the count of the provided key.
+func (c *CountMinSketch) Increment(key string) {
+	c.Add(key, 1)
+}
+
+// Add will add n to the count of the provided key.
+// Non-positive values of n are ignored.
+func (c *CountMinSketch) Add(key string, n int) {
+	if n <= 0 {
+		return
+	}
+	c.init()
+	c.total += n
+
+	h1, h2 := c.hashes(key)
+	if !c.conservative {
+		for row := 0; row < c.depth; row++ {
+			c.counts[c.cell(row, h1, h2)] += n
+		}
+		return
+	}
+
+	target := c.estimate(h1, h2) + n
+	for row := 0; row < c.depth; row++ {
+		i := c.cell(row, h1, h2)
+		if c.counts[i] < target {
+			c.counts[i] = target
+		}
+	}
+}
+
+// AddMap will add the counts of every key of an existing MapStringInt.
+func (c *CountMinSketch) AddMap(m MapStringInt) {
+	for key, n := range m {
+		c.Add(key, n)
+	}
+}
+
+// Estimate will return the estimated count of the provided key.
+func (c *CountMinSketch) Estimate(key string) int {
+	c.init()
+	h1, h2 := c.hashes(key)
+	return c.estimate(h1, h2)
+}
+
+// Get is an alias of Estimate that mirrors map lookups.
+func (c *CountMinSketch) Get(key string) int {
+	return c.Estimate(key)
+}
+
+// Merge will add the counts of another sketch with the same
+// width and depth into this sketch.
+func (c *CountMinSketch) Merge(other *CountMinSketch) error {
+	c.init()
+	other.init()
+	if c.width != other.width || c.depth != other.depth {
+		return ErrSketchDimensions
+	}
+
+	for i, n := range other.counts {
+		c.counts[i] += n
+	}
+	c.total += other.total
+
+	return nil
+}
+
+// init will give the zero CountMinSketch its default dimensions.
+func (c *CountMinSketch) init() {
+	if c.counts != nil {
+		return
+	}
+
+	c.width = countMinSketchWidth(DefaultCountMinSketchEpsilon)
+	c.depth = countMinSketchDepth(DefaultCountMinSketchDelta)
+	c.counts = make([]int, c.width*c.depth)
+}
+
+// estimate will return the smallest counter for the key.
+func (c *CountMinSketch) estimate(h1, h2 uint64) int {
+	min := math.MaxInt
+	for row := 0; row < c.depth; row++ {
+		if n := c.counts[c.cell(row, h1, h2)]; n < min {
+			min = n
+		}
+	}
+
+	return min
+}
+
+// hashes will return the two hashes that are combined to
+// index every row (Kirsch-Mitzenmacher double hashing).
+func (c *CountMinSketch) hashes(key string) (uint64, uint64) {
+	return hashString(key, 0), hashString(key, 0x9e3779b97f4a7c15) | 1
+}
+
+// cell will return the index of the key's counter in the row.
+func (c *CountMinSketch) cell(row int, h1, h2 uint64) int {
+	return row*c.width + int((h1+uint64(row)*h2)%uint64(c.width))
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCountMinSketchZeroValue(t *testing.T) {
+	var c CountMinSketch
+	if n := c.Estimate("x"); n != 0 {
+		t.Errorf("Estimate() of the zero CountMinSketch = %d, want 0", n)
+	}
+
+	c.Add("x", 3)
+	c.Increment("y")
+	if n := c.Estimate("x"); n < 3 {
+		t.Errorf("Estimate(x) = %d, want at least 3", n)
+	}
+
+	d := NewCountMinSketchWithError(DefaultCountMinSketchEpsilon, DefaultCountMinSketchDelta, false)
+	if c.Width() != d.Width() || c.Depth() != d.Depth() {
+		t.Errorf("zero CountMinSketch is %dx%d, want %dx%d", c.Width(), c.Depth(), d.Width(), d.Depth())
+	}
+	if err := d.Merge(&c); err != nil || d.Total() != 4 {
+		t.Errorf("Merge() of the zero CountMinSketch = %v with total %d, want nil with 4", err, d.Total())
+	}
+}
+
+func TestCountMinSketchWithInvalidError(t *testing.T) {
+	d := NewCountMinSketchWithError(DefaultCountMinSketchEpsilon, DefaultCountMinSketchDelta, false)
+
+	for _, v := range []float64{0, -1, 1, 2, math.NaN(), math.Inf(1)} {
+		c := NewCountMinSketchWithError(v, v, false)
+		if c.Width() != d.Width() || c.Depth() != d.Depth() {
+			t.Errorf("NewCountMinSketchWithError(%v, %v) is %dx%d, want %dx%d", v, v, c.Width(), c.Depth(), d.Width(), d.Depth())
+		}
+	}
+}
@@ -0,0 +1,309 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+const (
+	cuckooVersion    = 1
+	cuckooBucketSize = 4
+	cuckooMaxKicks   = 500
+)
+
+// CuckooFilter is a probabilistic set that, unlike a BloomFilter,
+// supports removing values. It stores a 16-bit fingerprint of every
+// value in buckets of four, which gives a false positive rate of
+// about 0.01%. Only values that were added may be deleted, otherwise
+// a different value sharing its fingerprint may be removed.
+//
+// The zero CuckooFilter is empty and ready to use, with room
+// for DefaultFilterCapacity values.
+type CuckooFilter struct {
+	buckets [][cuckooBucketSize]uint16
+	count   uint64
+}
+
+// NewCuckooFilter will return an empty CuckooFilter
+// with room for at least n values. Values of n less than 1
+// are treated as 1.
+func NewCuckooFilter(n int) *CuckooFilter {
+	if n < 1 {
+		n = 1
+	}
+
+	buckets := uint64(n)/cuckooBucketSize + 1
+	buckets = uint64(1) << bits.Len64(buckets-1)
+	if float64(n) > 0.95*float64(buckets*cuckooBucketSize) {
+		buckets *= 2
+	}
+
+	return &CuckooFilter{
+		buckets: make([][cuckooBucketSize]uint16, buckets),
+	}
+}
+
+// CuckooFilter will return a CuckooFilter containing every value
+// of the slice. Values that do not fit are not added.
+func (s SliceString) CuckooFilter() *CuckooFilter {
+	c := NewCuckooFilter(len(s))
+	for _, v := range s {
+		c.Add(v)
+	}
+
+	return c
+}
+
+// CuckooFilter will return a CuckooFilter containing every value
+// of the slice. Values that do not fit are not added.
+func (s SliceInt) CuckooFilter() *CuckooFilter {
+	c := NewCuckooFilter(len(s))
+	for _, v := range s {
+		c.AddInt(v)
+	}
+
+	return c
+}
+
+// CuckooFilter will return a CuckooFilter containing every value
+// of the slice. Values that do not fit are not added.
+func (s SliceFloat64) CuckooFilter() *CuckooFilter {
+	c := NewCuckooFilter(len(s))
+	for _, v := range s {
+		c.AddFloat64(v)
+	}
+
+	return c
+}
+
+// Count will return the number of values in the filter.
+func (c *CuckooFilter) Count() int {
+	return int(c.count)
+}
+
+// Add will add a string to the filter and return false
+// if the filter is too full to hold it.
+func (c *CuckooFilter) Add(value string) bool {
+	return c.addHash(hashString(value, 0))
+}
+
+// AddInt will add an integer to the filter and return false
+// if the filter is too full to hold it.
+func (c *CuckooFilter) AddInt(value int) bool {
+	return c.addHash(hashInt(value))
+}
+
+// AddFloat64 will add a float64 to the filter and return false
+// if the filter is too full to hold it.
+func (c *CuckooFilter) AddFloat64(value float64) bool {
+	return c.addHash(hashFloat64(value))
+}
+
+// Contains will return false if the string is definitely
+// not in the filter, and true if it probably is.
+func (c *CuckooFilter) Contains(value string) bool {
+	return c.containsHash(hashString(value, 0))
+}
+
+// ContainsInt will return false if the integer is definitely
+// not in the filter, and true if it probably is.
+func (c *CuckooFilter) ContainsInt(value int) bool {
+	return c.containsHash(hashInt(value))
+}
+
+// ContainsFloat64 will return false if the float64 is definitely
+// not in the filter, and true if it probably is.
+func (c *CuckooFilter) ContainsFloat64(value float64) bool {
+	return c.containsHash(hashFloat64(value))
+}
+
+// Delete will remove one copy of a string from the filter
+// and return whether it was found.
+func (c *CuckooFilter) Delete(value string) bool {
+	return c.deleteHash(hashString(value, 0))
+}
+
+// DeleteInt will remove one copy of an integer from the
+// filter and return whether it was found.
+func (c *CuckooFilter) DeleteInt(value int) bool {
+	return c.deleteHash(hashInt(value))
+}
+
+// DeleteFloat64 will remove one copy of a float64 from the
+// filter and return whether it was found.
+func (c *CuckooFilter) DeleteFloat64(value float64) bool {
+	return c.deleteHash(hashFloat64(value))
+}
+
+// MarshalBinary will encode the filter into a stable
+// big-endian binary format.
+func (c *CuckooFilter) MarshalBinary() ([]byte, error) {
+	c.init()
+	data := make([]byte, 0, 17+2*cuckooBucketSize*len(c.buckets))
+	data = append(data, cuckooVersion)
+	data = binary.BigEndian.AppendUint64(data, uint64(len(c.buckets)))
+	data = binary.BigEndian.AppendUint64(data, c.count)
+	for _, bucket := range c.buckets {
+		for _, fp := range bucket {
+			data = binary.BigEndian.AppendUint16(data, fp)
+		}
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary will decode a filter encoded by MarshalBinary,
+// replacing the contents of this filter.
+func (c *CuckooFilter) UnmarshalBinary(data []byte) error {
+	if len(data) < 17 || data[0] != cuckooVersion {
+		return ErrFilterEncoding
+	}
+
+	n := binary.BigEndian.Uint64(data[1:])
+	count := binary.BigEndian.Uint64(data[9:])
+	if n == 0 || n&(n-1) != 0 || uint64(len(data)-17) != 2*cuckooBucketSize*n {
+		return ErrFilterEncoding
+	}
+
+	buckets := make([][cuckooBucketSize]uint16, n)
+	offset := 17
+	for i := range buckets {
+		for j := range buckets[i] {
+			buckets[i][j] = binary.BigEndian.Uint16(data[offset:])
+			offset += 2
+		}
+	}
+
+	c.buckets, c.count = buckets, count
+	return nil
+}
+
+// locate will return the fingerprint of the hash and
+// the two buckets it may be stored in.
+func (c *CuckooFilter) locate(h uint64) (fp uint16, i1, i2 uint64) {
+	fp = uint16(h >> 48)
+	if fp == 0 {
+		fp = 1
+	}
+
+	i1 = h & c.mask()
+	return fp, i1, c.alternate(i1, fp)
+}
+
+// alternate will return the other bucket of a fingerprint
+// stored in bucket i.
+func (c *CuckooFilter) alternate(i uint64, fp uint16) uint64 {
+	return (i ^ hashUint64(uint64(fp), 0)) & c.mask()
+}
+
+func (c *CuckooFilter) mask() uint64 {
+	return uint64(len(c.buckets)) - 1
+}
+
+// init will size the zero CuckooFilter with the default capacity.
+func (c *CuckooFilter) init() {
+	if len(c.buckets) == 0 {
+		*c = *NewCuckooFilter(DefaultFilterCapacity)
+	}
+}
+
+func (c *CuckooFilter) addHash(h uint64) bool {
+	c.init()
+
+	fp, i1, i2 := c.locate(h)
+	if c.insert(i1, fp) || c.insert(i2, fp) {
+		c.count++
+		return true
+	}
+
+	// evict fingerprints to their alternate buckets, undoing
+	// the evictions if no free slot is found.
+	type move struct {
+		bucket uint64
+		slot   int
+		fp     uint16
+	}
+	var moves []move
+
+	i := i1
+	for kick := 0; kick < cuckooMaxKicks; kick++ {
+		slot := kick % cuckooBucketSize
+		evicted := c.buckets[i][slot]
+		c.buckets[i][slot] = fp
+		moves = append(moves, move{bucket: i, slot: slot, fp: evicted})
+
+		fp = evicted
+		i = c.alternate(i, fp)
+		if c.insert(i, fp) {
+			c.count++
+			return true
+		}
+	}
+
+	for m := len(moves) - 1; m >= 0; m-- {
+		c.buckets[moves[m].bucket][moves[m].slot] = moves[m].fp
+	}
+
+	return false
+}
+
+func (c *CuckooFilter) insert(i uint64, fp uint16) bool {
+	for slot, v := range c.buckets[i] {
+		if v == 0 {
+			c.buckets[i][slot] = fp
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *CuckooFilter) containsHash(h uint64) bool {
+	if len(c.buckets) == 0 {
+		return false
+	}
+
+	fp, i1, i2 := c.locate(h)
+	for _, i := range [2]uint64{i1, i2} {
+		for _, v := range c.buckets[i] {
+			if v == fp {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (c *CuckooFilter) deleteHash(h uint64) bool {
+	if len(c.buckets) == 0 {
+		return false
+	}
+
+	fp, i1, i2 := c.locate(h)
+	for _, i := range [2]uint64{i1, i2} {
+		for slot, v := range c.buckets[i] {
+			if v == fp {
+				c.buckets[i][slot] = 0
+				c.count--
+				return true
+			}
+		}
+	}
+
+	return false
+}
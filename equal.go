@@ -0,0 +1,186 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "slices"
+
+// Every slice type provides three equality modes, all in O(n):
+//
+//   - EqualOrdered: same length and the same values in the same positions.
+//     This is also the contract of the Slice interface's Equal method.
+//   - EqualAsSet: the same distinct values, ignoring order and repetition.
+//   - EqualAsMultiset: the same values the same number of times, ignoring order.
+//
+// Float comparisons are exact, so NaN is never equal to anything.
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func EqualOrdered[S ~[]E, E comparable](a, b S) bool {
+	return slices.Equal(a, b)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func EqualAsSet[S ~[]E, E comparable](a, b S) bool {
+	return NewSet(a...).Equal(NewSet(b...))
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func EqualAsMultiset[S ~[]E, E comparable](a, b S) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[E]int, len(a))
+	for _, v := range a {
+		counts[v]++
+	}
+	for _, v := range b {
+		n, ok := counts[v]
+		if !ok || n == 0 {
+			return false
+		}
+		counts[v] = n - 1
+	}
+
+	return true
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceInt) EqualOrdered(other SliceInt) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceInt) EqualAsSet(other SliceInt) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceInt) EqualAsMultiset(other SliceInt) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceInt64) EqualOrdered(other SliceInt64) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceInt64) EqualAsSet(other SliceInt64) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceInt64) EqualAsMultiset(other SliceInt64) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceFloat64) EqualOrdered(other SliceFloat64) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceFloat64) EqualAsSet(other SliceFloat64) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceFloat64) EqualAsMultiset(other SliceFloat64) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceString) EqualOrdered(other SliceString) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceString) EqualAsSet(other SliceString) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceString) EqualAsMultiset(other SliceString) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceBool) EqualOrdered(other SliceBool) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceBool) EqualAsSet(other SliceBool) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceBool) EqualAsMultiset(other SliceBool) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s SliceOf[T]) EqualOrdered(other SliceOf[T]) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s SliceOf[T]) EqualAsSet(other SliceOf[T]) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s SliceOf[T]) EqualAsMultiset(other SliceOf[T]) bool {
+	return EqualAsMultiset(s, other)
+}
+
+// EqualOrdered will check if both slices have the same
+// values in the same positions.
+func (s NumberSlice[T]) EqualOrdered(other NumberSlice[T]) bool {
+	return EqualOrdered(s, other)
+}
+
+// EqualAsSet will check if both slices contain the same
+// distinct values, regardless of order or repetition.
+func (s NumberSlice[T]) EqualAsSet(other NumberSlice[T]) bool {
+	return EqualAsSet(s, other)
+}
+
+// EqualAsMultiset will check if both slices contain the same
+// values the same number of times, regardless of order.
+func (s NumberSlice[T]) EqualAsMultiset(other NumberSlice[T]) bool {
+	return EqualAsMultiset(s, other)
+}
@@ -0,0 +1,182 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "math"
+
+// EWMOptions configures exponentially weighted statistics.
+// The decay is set by the first non-zero field of Alpha, Span,
+// HalfLife and CenterOfMass, in that order. If none are set
+// the smoothing factor is 1 and no smoothing takes place.
+type EWMOptions struct {
+	// Alpha is the smoothing factor, in (0,1].
+	Alpha float64
+	// Span gives alpha = 2 / (span + 1), for span >= 1.
+	Span float64
+	// HalfLife gives alpha = 1 - exp(-ln(2) / halflife), for halflife > 0.
+	HalfLife float64
+	// CenterOfMass gives alpha = 1 / (1 + com), for com >= 0.
+	CenterOfMass float64
+	// Adjust will divide by the decaying sum of weights so that the
+	// beginning of the series is not biased towards the first value.
+	// Without it the recursive form m = (1-alpha)*m + alpha*x is used.
+	Adjust bool
+	// Bias will return the biased (population) variance instead of
+	// the bias corrected variance.
+	Bias bool
+}
+
+// alpha will return the smoothing factor described by the options.
+func (o EWMOptions) alpha() float64 {
+	var alpha float64
+	switch {
+	case o.Alpha != 0:
+		alpha = o.Alpha
+	case o.Span != 0:
+		alpha = 2 / (o.Span + 1)
+	case o.HalfLife != 0:
+		alpha = 1 - math.Exp(-math.Ln2/o.HalfLife)
+	case o.CenterOfMass != 0:
+		alpha = 1 / (1 + o.CenterOfMass)
+	default:
+		alpha = 1
+	}
+
+	return math.Max(math.Min(alpha, 1), math.SmallestNonzeroFloat64)
+}
+
+// EWM incrementally accumulates the exponentially weighted
+// mean and variance of the values pushed to it.
+// NaN values are skipped and do not decay the weights.
+type EWM struct {
+	alpha  float64
+	adjust bool
+	bias   bool
+
+	n          int
+	mean       float64
+	m2         float64
+	sumWeight  float64
+	sumWeight2 float64
+}
+
+// NewEWM will return an EWM accumulator configured by the options.
+func NewEWM(opts EWMOptions) *EWM {
+	return &EWM{
+		alpha:  opts.alpha(),
+		adjust: opts.Adjust,
+		bias:   opts.Bias,
+	}
+}
+
+// Push will add a value to the accumulator.
+func (e *EWM) Push(x float64) {
+	if math.IsNaN(x) {
+		return
+	}
+
+	decay := 1 - e.alpha
+	weight := 1.0
+	if !e.adjust && e.n > 0 {
+		weight = e.alpha
+	}
+
+	e.n++
+	e.sumWeight = decay*e.sumWeight + weight
+	e.sumWeight2 = decay*decay*e.sumWeight2 + weight*weight
+	e.m2 *= decay
+
+	delta := x - e.mean
+	e.mean += weight / e.sumWeight * delta
+	e.m2 += weight * delta * (x - e.mean)
+}
+
+// PushSlice will add every value of the slice to the accumulator.
+func (e *EWM) PushSlice(s SliceFloat64) {
+	for _, v := range s {
+		e.Push(v)
+	}
+}
+
+// Count will return the number of non-NaN values pushed.
+func (e *EWM) Count() int {
+	return e.n
+}
+
+// Mean will return the exponentially weighted mean, or
+// NaN if no values have been pushed.
+func (e *EWM) Mean() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+
+	return e.mean
+}
+
+// Variance will return the exponentially weighted variance, or
+// NaN if no values have been pushed. Unless the Bias option is set
+// the variance is bias corrected, and is 0 for a single value.
+func (e *EWM) Variance() float64 {
+	if e.n == 0 {
+		return math.NaN()
+	}
+
+	if e.bias {
+		return e.m2 / e.sumWeight
+	}
+
+	denominator := e.sumWeight*e.sumWeight - e.sumWeight2
+	if denominator <= 0 {
+		return 0
+	}
+
+	return e.m2 * e.sumWeight / denominator
+}
+
+// StdDev will return the exponentially weighted standard deviation.
+func (e *EWM) StdDev() float64 {
+	return math.Sqrt(e.Variance())
+}
+
+// EWMMean will return the exponentially weighted mean at
+// every position of the slice.
+func (s SliceFloat64) EWMMean(opts EWMOptions) SliceFloat64 {
+	return s.ewm(opts, (*EWM).Mean)
+}
+
+// EWMVariance will return the exponentially weighted variance
+// at every position of the slice.
+func (s SliceFloat64) EWMVariance(opts EWMOptions) SliceFloat64 {
+	return s.ewm(opts, (*EWM).Variance)
+}
+
+// EWMStdDev will return the exponentially weighted standard
+// deviation at every position of the slice.
+func (s SliceFloat64) EWMStdDev(opts EWMOptions) SliceFloat64 {
+	return s.ewm(opts, (*EWM).StdDev)
+}
+
+// ewm will push every value of the slice into a new EWM and
+// record the statistic after each one.
+func (s SliceFloat64) ewm(opts EWMOptions, stat func(*EWM) float64) SliceFloat64 {
+	e := NewEWM(opts)
+	results := make(SliceFloat64, len(s))
+	for i, v := range s {
+		e.Push(v)
+		results[i] = stat(e)
+	}
+
+	return results
+}
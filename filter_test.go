@@ -0,0 +1,70 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "testing"
+
+func TestFilterZeroValues(t *testing.T) {
+	var b BloomFilter
+	if b.Contains("a") || b.ContainsInt(1) || b.ContainsFloat64(1) {
+		t.Error("the zero BloomFilter contains a value")
+	}
+
+	b.Add("a")
+	b.AddInt(1)
+	if !b.Contains("a") || !b.ContainsInt(1) {
+		t.Error("the zero BloomFilter lost an added value")
+	}
+	if want := NewBloomFilter(DefaultFilterCapacity, DefaultBloomFilterFalsePositiveRate); b.size != want.size || b.hashes != want.hashes {
+		t.Errorf("zero BloomFilter has size %d and %d hashes, want %d and %d", b.size, b.hashes, want.size, want.hashes)
+	}
+
+	var c CuckooFilter
+	if c.Contains("a") || c.Delete("a") || c.Count() != 0 {
+		t.Error("the zero CuckooFilter contains a value")
+	}
+	if !c.Add("a") || !c.AddInt(1) || !c.AddFloat64(1) || c.Count() != 3 {
+		t.Error("the zero CuckooFilter did not add a value")
+	}
+	if !c.Contains("a") || !c.Delete("a") || c.Contains("a") {
+		t.Error("the zero CuckooFilter did not delete a value")
+	}
+
+	var empty BloomFilter
+	data, err := empty.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(BloomFilter).UnmarshalBinary(data); err != nil {
+		t.Errorf("decoding the zero BloomFilter returned %v", err)
+	}
+
+	var emptyCuckoo CuckooFilter
+	if data, err = emptyCuckoo.MarshalBinary(); err != nil {
+		t.Fatal(err)
+	}
+	if err := new(CuckooFilter).UnmarshalBinary(data); err != nil {
+		t.Errorf("decoding the zero CuckooFilter returned %v", err)
+	}
+}
+
+func TestCuckooFilterNonPositiveSize(t *testing.T) {
+	for _, n := range []int{0, -1, -1000} {
+		c := NewCuckooFilter(n)
+		if !c.Add("a") || !c.Contains("a") {
+			t.Errorf("NewCuckooFilter(%d) cannot hold a value", n)
+		}
+	}
+}
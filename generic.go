@@ -0,0 +1,394 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Number is a constraint that permits any integer
+// or floating-point type.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// SliceOf is a type-parameterized slice of comparable values.
+// It satisfies the Slice interface for any element type.
+type SliceOf[T comparable] []T
+
+// Equal will only return true if the object is a SliceOf[T]
+// of the same length and has the same values in the same positions.
+func (s SliceOf[T]) Equal(input interface{}) bool {
+	return equalSlice(s, input)
+}
+
+// Get will return the value at the specified index.
+func (s SliceOf[T]) Get(idx int) interface{} {
+	return s[idx]
+}
+
+// Len will return the length of the slice.
+func (s SliceOf[T]) Len() int {
+	return len(s)
+}
+
+// Set will set the provided value at the provided index
+// if the value is of the slice's element type.
+func (s SliceOf[T]) Set(idx int, value interface{}) {
+	setValue(s, idx, value)
+}
+
+// Type will return the type name of the values stored.
+func (s SliceOf[T]) Type() string {
+	return TypeOf[T]()
+}
+
+// Subslice will return a new SliceOf[T] that is a subslice of
+// this slice.
+func (s SliceOf[T]) Subslice(start, end int) Slice {
+	return s[start:end]
+}
+
+// Contains checks if a value already exists in the slice.
+func (s SliceOf[T]) Contains(v T) bool {
+	return Contains(s, v)
+}
+
+// Index will return the index of the first occurrence
+// of the value, or -1 if it is not present.
+func (s SliceOf[T]) Index(v T) int {
+	return Index(s, v)
+}
+
+// NumberSlice is a type-parameterized slice of numbers.
+// It satisfies the Slice interface and provides the arithmetic
+// helpers shared by all of the numeric slice types.
+type NumberSlice[T Number] []T
+
+// Equal will only return true if the object is a NumberSlice[T]
+// of the same length and has the same values in the same positions.
+func (s NumberSlice[T]) Equal(input interface{}) bool {
+	return equalSlice(s, input)
+}
+
+// Get will return the value at the specified index.
+func (s NumberSlice[T]) Get(idx int) interface{} {
+	return s[idx]
+}
+
+// Len will return the length of the slice.
+func (s NumberSlice[T]) Len() int {
+	return len(s)
+}
+
+// Set will set the provided value at the provided index
+// if the value is of the slice's element type.
+func (s NumberSlice[T]) Set(idx int, value interface{}) {
+	setValue(s, idx, value)
+}
+
+// Type will return the type name of the values stored.
+func (s NumberSlice[T]) Type() string {
+	return TypeOf[T]()
+}
+
+// Subslice will return a new NumberSlice[T] that is a subslice of
+// this slice.
+func (s NumberSlice[T]) Subslice(start, end int) Slice {
+	return s[start:end]
+}
+
+// Contains checks if a value already exists in the slice.
+func (s NumberSlice[T]) Contains(v T) bool {
+	return Contains(s, v)
+}
+
+// Sum will return the total sum of all values in the slice.
+func (s NumberSlice[T]) Sum() T {
+	return Sum(s)
+}
+
+// Product will return the total product of all values in the slice.
+func (s NumberSlice[T]) Product() T {
+	return Product(s)
+}
+
+// Min will return the index and the value of the
+// smallest value in the slice.
+func (s NumberSlice[T]) Min() (int, T) {
+	return Min(s)
+}
+
+// Max will return the index and the value of the
+// largest value in the slice.
+func (s NumberSlice[T]) Max() (int, T) {
+	return Max(s)
+}
+
+// IncrementPosition will add 1 to the value found
+// at the supplied index argument.
+func (s NumberSlice[T]) IncrementPosition(index int) {
+	IncrementPosition(s, index)
+}
+
+// Map is a type-parameterized map with numerical values.
+// It can be built by simply calling make(Map[K, V]).
+type Map[K comparable, V Number] map[K]V
+
+// Increment will add 1 to the value found at the provided key.
+func (m Map[K, V]) Increment(key K) {
+	m[key]++
+}
+
+// Contains will return whether or not a key exists
+// in the map.
+func (m Map[K, V]) Contains(key K) bool {
+	_, ok := m[key]
+	return ok
+}
+
+// Keys will return the slice of all keys found in the map.
+func (m Map[K, V]) Keys() SliceOf[K] {
+	return Keys(m)
+}
+
+// Values will return the slice of all values found in the map.
+func (m Map[K, V]) Values() NumberSlice[V] {
+	return Values(m)
+}
+
+// KeysAndValues will return two ordered sets of keys and values that are aligned
+// by slice index.
+func (m Map[K, V]) KeysAndValues() (SliceOf[K], NumberSlice[V]) {
+	return KeysAndValues(m)
+}
+
+// MaxValue will return the largest value and it's key.
+func (m Map[K, V]) MaxValue() (K, V) {
+	return MaxValue(m)
+}
+
+// MinValue will return the smallest value and it's key.
+func (m Map[K, V]) MinValue() (K, V) {
+	return MinValue(m)
+}
+
+// TypeOf will return the type name of T in the same
+// form as BoolType, Float64Type, etc.
+func TypeOf[T any]() string {
+	var zero T
+	return fmt.Sprint(reflect.TypeOf(zero))
+}
+
+// equalSlice will only return true if the input is of the same
+// slice type as s, has the same length, and has the same values
+// in the same positions.
+func equalSlice[S ~[]E, E comparable](s S, input interface{}) bool {
+	sl, ok := input.(S)
+	if !ok {
+		return false
+	}
+
+	if len(sl) != len(s) {
+		return false
+	}
+
+	for i, v := range s {
+		if sl[i] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// setValue will set the provided value at the provided index
+// if the value is of the slice's element type.
+func setValue[S ~[]E, E any](s S, idx int, value interface{}) {
+	v, ok := value.(E)
+	if ok {
+		s[idx] = v
+	}
+}
+
+// Contains checks if a value already exists in the slice.
+func Contains[S ~[]E, E comparable](s S, value E) bool {
+	return Index(s, value) >= 0
+}
+
+// Index will return the index of the first occurrence
+// of the value, or -1 if it is not present.
+func Index[S ~[]E, E comparable](s S, value E) int {
+	for i, v := range s {
+		if v == value {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Sum will return the total sum of all values in the slice.
+func Sum[S ~[]E, E Number](s S) (sum E) {
+	for _, v := range s {
+		sum += v
+	}
+
+	return
+}
+
+// Product will return the total product of all values in the slice.
+// The product of an empty slice is 0.
+func Product[S ~[]E, E Number](s S) (product E) {
+	for i, v := range s {
+		if i == 0 {
+			product = v
+			continue
+		}
+		product *= v
+	}
+
+	return
+}
+
+// Min will return the index and the value of the
+// smallest value in the slice. NaN values are ignored.
+// An empty slice returns an index of -1, as does a slice
+// of only NaN values, along with a NaN value.
+func Min[S ~[]E, E Number](s S) (index int, value E) {
+	if len(s) == 0 {
+		return -1, value
+	}
+
+	index = -1
+	for i, v := range s {
+		if isNaN(v) {
+			continue
+		}
+		if index < 0 || v < value {
+			index = i
+			value = v
+		}
+	}
+	if index < 0 {
+		value = s[0]
+	}
+
+	return
+}
+
+// Max will return the index and the value of the
+// largest value in the slice. NaN values are ignored.
+// An empty slice returns an index of -1, as does a slice
+// of only NaN values, along with a NaN value.
+func Max[S ~[]E, E Number](s S) (index int, value E) {
+	if len(s) == 0 {
+		return -1, value
+	}
+
+	index = -1
+	for i, v := range s {
+		if isNaN(v) {
+			continue
+		}
+		if index < 0 || v > value {
+			index = i
+			value = v
+		}
+	}
+	if index < 0 {
+		value = s[0]
+	}
+
+	return
+}
+
+// IncrementPosition will add 1 to the value found
+// at the supplied index argument.
+func IncrementPosition[S ~[]E, E Number](s S, index int) {
+	if index > len(s)-1 || index < 0 {
+		return
+	}
+
+	s[index]++
+}
+
+// Keys will return the slice of all keys found in the map.
+func Keys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// Values will return the slice of all values found in the map.
+func Values[M ~map[K]V, K comparable, V any](m M) []V {
+	values := make([]V, 0, len(m))
+	for _, v := range m {
+		values = append(values, v)
+	}
+
+	return values
+}
+
+// KeysAndValues will return two ordered sets of keys and values that are aligned
+// by slice index.
+func KeysAndValues[M ~map[K]V, K comparable, V any](m M) (keys []K, values []V) {
+	keys = make([]K, 0, len(m))
+	values = make([]V, 0, len(m))
+	for k, v := range m {
+		keys = append(keys, k)
+		values = append(values, v)
+	}
+
+	return
+}
+
+// MaxValue will return the largest value and it's key.
+// The key is the first result returned. And the value
+// is the second result returned.
+func MaxValue[M ~map[K]V, K comparable, V Number](m M) (key K, value V) {
+	first := true
+	for k, v := range m {
+		if first || v > value {
+			key = k
+			value = v
+			first = false
+		}
+	}
+
+	return
+}
+
+// MinValue will return the smallest value and it's key.
+// The key is the first result returned. And the value
+// is the second result returned.
+func MinValue[M ~map[K]V, K comparable, V Number](m M) (key K, value V) {
+	first := true
+	for k, v := range m {
+		if first || v < value {
+			key = k
+			value = v
+			first = false
+		}
+	}
+
+	return
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinMaxIgnoreNaN(t *testing.T) {
+	nan := math.NaN()
+
+	for _, s := range []SliceFloat64{{nan, 1, 2}, {1, nan, 2}, {2, 1, nan}} {
+		if v := s.Min(); v != 1 {
+			t.Errorf("%v.Min() = %v, want 1", s, v)
+		}
+		if v := s.Max(); v != 2 {
+			t.Errorf("%v.Max() = %v, want 2", s, v)
+		}
+		if i, v := s.MinIndex(); s[i] != v || v != 1 {
+			t.Errorf("%v.MinIndex() = %d, %v, want the index of 1", s, i, v)
+		}
+		if i := s.MaxIndex(); s[i] != 2 {
+			t.Errorf("%v.MaxIndex() = %d, want the index of 2", s, i)
+		}
+	}
+
+	only := SliceFloat64{nan, nan}
+	if i, v := only.MinIndex(); i != -1 || !math.IsNaN(v) {
+		t.Errorf("MinIndex() of only NaN = %d, %v, want -1, NaN", i, v)
+	}
+	if i := only.MaxIndex(); i != -1 {
+		t.Errorf("MaxIndex() of only NaN = %d, want -1", i)
+	}
+	if i, v := Min(SliceFloat64{}); i != -1 || v != 0 {
+		t.Errorf("Min() of empty = %d, %v, want -1, 0", i, v)
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/humilityai/sam
 
-go 1.13
+go 1.21
 
 require github.com/humilityai/math v0.0.0-20200803033757-480d44b783d6
@@ -0,0 +1,66 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "math"
+
+// The sketches and filters in this package hash their keys with
+// these functions rather than hash/maphash so that sketches built
+// in different processes can be merged and serialized.
+
+// hashString will return a well mixed 64-bit hash of the string
+// that is stable across processes and platforms. It is FNV-1a
+// followed by the MurmurHash3 finalizer.
+func hashString(s string, seed uint64) uint64 {
+	h := uint64(14695981039346656037) ^ seed
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+
+	return mix64(h)
+}
+
+// hashUint64 will return a well mixed 64-bit hash of the
+// value that is stable across processes and platforms.
+func hashUint64(v uint64, seed uint64) uint64 {
+	return mix64(mix64(v^seed) + 0x9e3779b97f4a7c15)
+}
+
+// mix64 is the 64-bit finalizer of MurmurHash3.
+func mix64(h uint64) uint64 {
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+
+	return h
+}
+
+// hashInt will return a well mixed 64-bit hash of the integer.
+func hashInt(v int) uint64 {
+	return hashUint64(uint64(v), 0)
+}
+
+// hashFloat64 will return a well mixed 64-bit hash of the float,
+// treating 0 and -0 as the same value.
+func hashFloat64(v float64) uint64 {
+	if v == 0 {
+		v = 0
+	}
+
+	return hashUint64(math.Float64bits(v), 0)
+}
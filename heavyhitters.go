@@ -0,0 +1,298 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// HeavyHitters is a bounded alternative to MapStringInt for
+// counting the most frequent keys of a stream, using the
+// Space-Saving algorithm.
+//
+// At most capacity keys are tracked. The count reported for a
+// key never underestimates its true count and overestimates it
+// by at most the key's error, which is itself at most
+// Total()/capacity. Any key whose true count exceeds
+// Total()/capacity is guaranteed to be tracked.
+//
+// The zero HeavyHitters is empty and ready to use with a
+// capacity of DefaultHeavyHittersCapacity.
+type HeavyHitters struct {
+	capacity int
+	total    int
+	entries  map[string]*heavyHitter
+	heap     heavyHitterHeap
+}
+
+// heavyHitter is a tracked key with its (over)estimated
+// count and the maximum overestimation.
+type heavyHitter struct {
+	key   string
+	count int
+	err   int
+	index int
+}
+
+// DefaultHeavyHittersCapacity is the capacity of the zero HeavyHitters.
+const DefaultHeavyHittersCapacity = 100
+
+// NewHeavyHitters will return an empty HeavyHitters counter
+// that tracks at most capacity keys. A capacity of less than
+// 1 is treated as 1.
+func NewHeavyHitters(capacity int) *HeavyHitters {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &HeavyHitters{
+		capacity: capacity,
+		entries:  make(map[string]*heavyHitter, capacity),
+	}
+}
+
+// Increment will add 1 to the count of the provided key.
+func (h *HeavyHitters) Increment(key string) {
+	h.Add(key, 1)
+}
+
+// Add will add n to the count of the provided key.
+// Non-positive values of n are ignored.
+func (h *HeavyHitters) Add(key string, n int) {
+	if n <= 0 {
+		return
+	}
+	h.init()
+	h.total += n
+
+	if e, ok := h.entries[key]; ok {
+		e.count += n
+		heap.Fix(&h.heap, e.index)
+		return
+	}
+
+	if len(h.heap) < h.capacity {
+		e := &heavyHitter{key: key, count: n}
+		h.entries[key] = e
+		heap.Push(&h.heap, e)
+		return
+	}
+
+	// replace the key with the smallest count, inheriting
+	// its count as the new key's maximum overestimation.
+	e := h.heap[0]
+	delete(h.entries, e.key)
+	e.key = key
+	e.err = e.count
+	e.count += n
+	h.entries[key] = e
+	heap.Fix(&h.heap, 0)
+}
+
+// Contains will return whether or not a key is currently tracked.
+func (h *HeavyHitters) Contains(key string) bool {
+	_, ok := h.entries[key]
+	return ok
+}
+
+// Count will return the estimated count of the key and the
+// maximum amount by which it overestimates the true count.
+// Keys that are not tracked return a count of 0 and an error
+// equal to the smallest tracked count once the counter is full.
+func (h *HeavyHitters) Count(key string) (count, err int) {
+	if e, ok := h.entries[key]; ok {
+		return e.count, e.err
+	}
+
+	return 0, h.minCount()
+}
+
+// Total will return the sum of every count added.
+func (h *HeavyHitters) Total() int {
+	return h.total
+}
+
+// Len will return the number of keys currently tracked.
+func (h *HeavyHitters) Len() int {
+	return len(h.heap)
+}
+
+// Capacity will return the maximum number of keys tracked.
+func (h *HeavyHitters) Capacity() int {
+	h.init()
+	return h.capacity
+}
+
+// MaxValue will return the key with the largest estimated count
+// and its count.
+func (h *HeavyHitters) MaxValue() (key string, value int) {
+	keys, counts := h.TopK(1)
+	if len(keys) == 0 {
+		return
+	}
+
+	return keys[0], counts[0]
+}
+
+// TopK will return up to n tracked keys and their estimated counts,
+// ordered from the largest count to the smallest. Ties are ordered
+// by the smaller error, and then by key.
+func (h *HeavyHitters) TopK(n int) (keys SliceString, counts SliceInt) {
+	entries := h.sorted()
+	if n > len(entries) {
+		n = len(entries)
+	}
+	if n < 0 {
+		n = 0
+	}
+
+	keys = make(SliceString, n)
+	counts = make(SliceInt, n)
+	for i, e := range entries[:n] {
+		keys[i] = e.key
+		counts[i] = e.count
+	}
+
+	return
+}
+
+// ToMap will return the tracked keys and their estimated counts.
+func (h *HeavyHitters) ToMap() MapStringInt {
+	m := make(MapStringInt, len(h.entries))
+	for key, e := range h.entries {
+		m[key] = e.count
+	}
+
+	return m
+}
+
+// Merge will combine the counts of another counter, for example from
+// a different shard, into this counter. The merged counter keeps
+// this counter's capacity and the same error guarantees with respect
+// to the combined total.
+func (h *HeavyHitters) Merge(other *HeavyHitters) {
+	h.init()
+	other.init()
+	hMin, otherMin := h.minCount(), other.minCount()
+
+	combined := make(map[string]*heavyHitter, len(h.entries)+len(other.entries))
+	for key, e := range h.entries {
+		count, err := other.Count(key)
+		if _, ok := other.entries[key]; !ok {
+			count = otherMin
+		}
+		combined[key] = &heavyHitter{key: key, count: e.count + count, err: e.err + err}
+	}
+	for key, e := range other.entries {
+		if _, ok := combined[key]; ok {
+			continue
+		}
+		combined[key] = &heavyHitter{key: key, count: e.count + hMin, err: e.err + hMin}
+	}
+
+	entries := make([]*heavyHitter, 0, len(combined))
+	for _, e := range combined {
+		entries = append(entries, e)
+	}
+	sortHeavyHitters(entries)
+	if len(entries) > h.capacity {
+		entries = entries[:h.capacity]
+	}
+
+	h.total += other.total
+	h.entries = make(map[string]*heavyHitter, h.capacity)
+	h.heap = h.heap[:0]
+	for i, e := range entries {
+		e.index = i
+		h.entries[e.key] = e
+		h.heap = append(h.heap, e)
+	}
+	heap.Init(&h.heap)
+}
+
+// init will give the zero HeavyHitters the default
+// capacity and its map of tracked keys.
+func (h *HeavyHitters) init() {
+	if h.capacity == 0 {
+		h.capacity = DefaultHeavyHittersCapacity
+	}
+	if h.entries == nil {
+		h.entries = make(map[string]*heavyHitter, h.capacity)
+	}
+}
+
+// minCount will return the smallest tracked count if the
+// counter is full, and 0 otherwise.
+func (h *HeavyHitters) minCount() int {
+	if len(h.heap) == 0 || len(h.heap) < h.capacity {
+		return 0
+	}
+
+	return h.heap[0].count
+}
+
+// sorted will return the tracked entries ordered
+// from the largest count to the smallest.
+func (h *HeavyHitters) sorted() []*heavyHitter {
+	entries := make([]*heavyHitter, len(h.heap))
+	copy(entries, h.heap)
+	sortHeavyHitters(entries)
+
+	return entries
+}
+
+func sortHeavyHitters(entries []*heavyHitter) {
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.count != b.count {
+			return a.count > b.count
+		}
+		if a.err != b.err {
+			return a.err < b.err
+		}
+		return a.key < b.key
+	})
+}
+
+// heavyHitterHeap is a min-heap of entries by count.
+type heavyHitterHeap []*heavyHitter
+
+func (h heavyHitterHeap) Len() int {
+	return len(h)
+}
+
+func (h heavyHitterHeap) Less(i, j int) bool {
+	return h[i].count < h[j].count
+}
+
+func (h heavyHitterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *heavyHitterHeap) Push(x interface{}) {
+	e := x.(*heavyHitter)
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+
+func (h *heavyHitterHeap) Pop() interface{} {
+	old := *h
+	e := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return e
+}
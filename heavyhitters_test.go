@@ -0,0 +1,88 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// checkHeap will fail the test if the heap indices or
+// the min-heap ordering of the counter are broken.
+func checkHeap(t *testing.T, h *HeavyHitters) {
+	t.Helper()
+
+	for i, e := range h.heap {
+		if e.index != i {
+			t.Fatalf("entry %q at heap slot %d has index %d", e.key, i, e.index)
+		}
+		if i > 0 && h.heap[(i-1)/2].count > e.count {
+			t.Fatalf("heap slot %d has count %d below its parent's %d", i, e.count, h.heap[(i-1)/2].count)
+		}
+	}
+}
+
+func TestHeavyHittersMergeTiedCounts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 1000; trial++ {
+		a, b := NewHeavyHitters(8), NewHeavyHitters(8)
+		for i := 0; i < 8; i++ {
+			a.Add(fmt.Sprint("a", i), 5)
+			b.Add(fmt.Sprint("b", i), 5)
+		}
+
+		a.Merge(b)
+		checkHeap(t, a)
+
+		for i := 0; i < 3; i++ {
+			a.Increment(a.heap[r.Intn(a.Len())].key)
+			checkHeap(t, a)
+		}
+
+		min := a.minCount()
+		a.Increment("new")
+		checkHeap(t, a)
+
+		// the new key must have replaced a key with the minimum count.
+		if count, err := a.Count("new"); count != min+1 || err != min {
+			t.Fatalf("new key has count %d and error %d, want %d and %d", count, err, min+1, min)
+		}
+	}
+}
+
+func TestHeavyHittersZeroValue(t *testing.T) {
+	var h HeavyHitters
+	if count, err := h.Count("x"); count != 0 || err != 0 {
+		t.Errorf("Count() of the zero HeavyHitters = %d, %d, want 0, 0", count, err)
+	}
+
+	for i := 0; i < 2*DefaultHeavyHittersCapacity; i++ {
+		h.Increment(fmt.Sprint("key", i%(DefaultHeavyHittersCapacity+10)))
+	}
+	checkHeap(t, &h)
+
+	if h.Capacity() != DefaultHeavyHittersCapacity || h.Len() != DefaultHeavyHittersCapacity {
+		t.Errorf("zero HeavyHitters has capacity %d and length %d, want %d", h.Capacity(), h.Len(), DefaultHeavyHittersCapacity)
+	}
+
+	var other HeavyHitters
+	other.Merge(&h)
+	checkHeap(t, &other)
+	if other.Total() != h.Total() {
+		t.Errorf("Merge() into the zero HeavyHitters has total %d, want %d", other.Total(), h.Total())
+	}
+}
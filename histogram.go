@@ -0,0 +1,343 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrHistogramEdges = errors.New("histograms have different edges")
+)
+
+// MaxHistogramBins is the most bins BinScott and BinFreedmanDiaconis
+// will choose, which bounds the memory used when a few outliers make
+// the range of the data much larger than its spread.
+const MaxHistogramBins = 10000
+
+// BinningStrategy selects how the bin edges of a
+// Histogram are chosen from the data.
+type BinningStrategy int
+
+const (
+	// BinEqualWidth splits the data range into the requested
+	// number of equally wide bins.
+	BinEqualWidth BinningStrategy = iota
+	// BinLogarithmic splits the positive data range into the
+	// requested number of bins that are equally wide on a log scale.
+	BinLogarithmic
+	// BinSturges uses ceil(log2(n)) + 1 equally wide bins.
+	BinSturges
+	// BinScott uses equally wide bins of width 3.49 * std * n^(-1/3),
+	// at most MaxHistogramBins of them. It falls back to BinSturges
+	// if the data has no spread.
+	BinScott
+	// BinFreedmanDiaconis uses equally wide bins of width 2 * IQR * n^(-1/3),
+	// at most MaxHistogramBins of them. It falls back to BinSturges
+	// if the IQR is 0.
+	BinFreedmanDiaconis
+)
+
+// Histogram counts values into bins defined by ascending edges.
+// Every bin includes its lower edge and excludes its upper edge,
+// except for the last bin which includes both.
+// Values outside of the edges are counted as underflow or overflow.
+type Histogram struct {
+	Edges     SliceFloat64 `json:"edges"`
+	Counts    SliceInt     `json:"counts"`
+	Underflow int          `json:"underflow"`
+	Overflow  int          `json:"overflow"`
+	NaNCount  int          `json:"nan_count"`
+}
+
+// NewHistogram will return an empty Histogram with the supplied
+// edges, which are copied and sorted. At least two edges are
+// needed to form a bin.
+func NewHistogram(edges SliceFloat64) *Histogram {
+	sorted := edges.Sorted()
+
+	bins := len(sorted) - 1
+	if bins < 0 {
+		bins = 0
+	}
+
+	return &Histogram{
+		Edges:  sorted,
+		Counts: make(SliceInt, bins),
+	}
+}
+
+// Histogram will return a Histogram of the values in the slice,
+// with edges chosen by the strategy. The bins argument is only
+// used by BinEqualWidth and BinLogarithmic.
+func (s SliceFloat64) Histogram(strategy BinningStrategy, bins int) *Histogram {
+	h := NewHistogram(s.HistogramEdges(strategy, bins))
+	h.AddSlice(s)
+	return h
+}
+
+// HistogramEdges will return the bin edges the strategy chooses
+// for the values in the slice. NaN and infinite values are ignored.
+func (s SliceFloat64) HistogramEdges(strategy BinningStrategy, bins int) SliceFloat64 {
+	finite := make(SliceFloat64, 0, len(s))
+	for _, v := range s {
+		if !math.IsNaN(v) && !math.IsInf(v, 0) {
+			finite = append(finite, v)
+		}
+	}
+
+	if strategy == BinLogarithmic {
+		return finite.logEdges(bins)
+	}
+
+	min, max := finite.Min(), finite.Max()
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+
+	n := float64(len(finite))
+	sturges := 1
+	if n > 0 {
+		sturges = int(math.Ceil(math.Log2(n))) + 1
+	}
+	var width float64
+	switch strategy {
+	case BinSturges:
+		bins = sturges
+	case BinScott:
+		width = 3.49 * finite.StdDev() * math.Cbrt(1/n)
+	case BinFreedmanDiaconis:
+		width = 2 * finite.IQR() * math.Cbrt(1/n)
+	}
+
+	if strategy == BinScott || strategy == BinFreedmanDiaconis {
+		// a width of 0 (no spread) or a non-finite width
+		// says nothing about the data, so use Sturges.
+		count := math.Ceil((max - min) / width)
+		switch {
+		case !(width > 0) || math.IsNaN(count) || math.IsInf(count, 0):
+			bins = sturges
+		case count > MaxHistogramBins:
+			bins = MaxHistogramBins
+		default:
+			bins = int(count)
+		}
+	}
+
+	return EqualWidthEdges(min, max, bins)
+}
+
+// logEdges will return logarithmically spaced edges that
+// span the positive values in the slice.
+func (s SliceFloat64) logEdges(bins int) SliceFloat64 {
+	positive := make(SliceFloat64, 0, len(s))
+	for _, v := range s {
+		if v > 0 {
+			positive = append(positive, v)
+		}
+	}
+
+	if len(positive) == 0 {
+		return LogEdges(1, 10, bins)
+	}
+
+	min, max := positive.Min(), positive.Max()
+	if min == max {
+		min, max = min/2, max*2
+	}
+
+	return LogEdges(min, max, bins)
+}
+
+// EqualWidthEdges will return the edges of bins equally
+// wide bins between min and max. At least one bin is returned.
+func EqualWidthEdges(min, max float64, bins int) SliceFloat64 {
+	if bins < 1 {
+		bins = 1
+	}
+
+	edges := make(SliceFloat64, bins+1)
+	width := (max - min) / float64(bins)
+	for i := range edges {
+		edges[i] = min + float64(i)*width
+	}
+	edges[bins] = max
+
+	return edges
+}
+
+// LogEdges will return the edges of bins bins between min and max
+// that are equally wide on a logarithmic scale. Both min and max
+// must be positive. At least one bin is returned.
+func LogEdges(min, max float64, bins int) SliceFloat64 {
+	edges := EqualWidthEdges(math.Log(min), math.Log(max), bins)
+	for i, v := range edges {
+		edges[i] = math.Exp(v)
+	}
+	edges[0], edges[len(edges)-1] = min, max
+
+	return edges
+}
+
+// Bin will return the index of the bin the value falls into,
+// -1 if it is below the edges, or len(Counts) if it is above them
+// (or is NaN).
+func (h *Histogram) Bin(v float64) int {
+	bins := len(h.Counts)
+	if bins == 0 || math.IsNaN(v) {
+		return bins
+	}
+
+	if v < h.Edges[0] {
+		return -1
+	}
+	if v > h.Edges[bins] {
+		return bins
+	}
+	if v == h.Edges[bins] {
+		return bins - 1
+	}
+
+	return sort.Search(bins, func(i int) bool {
+		return h.Edges[i+1] > v
+	})
+}
+
+// Add will count a value into the histogram.
+func (h *Histogram) Add(v float64) {
+	if math.IsNaN(v) {
+		h.NaNCount++
+		return
+	}
+
+	bin := h.Bin(v)
+	switch {
+	case bin < 0:
+		h.Underflow++
+	case bin >= len(h.Counts):
+		h.Overflow++
+	default:
+		h.Counts[bin]++
+	}
+}
+
+// AddSlice will count every value of the slice into the histogram.
+func (h *Histogram) AddSlice(s SliceFloat64) {
+	for _, v := range s {
+		h.Add(v)
+	}
+}
+
+// Total will return the number of values counted inside the edges.
+func (h *Histogram) Total() int {
+	return h.Counts.Sum()
+}
+
+// Widths will return the width of every bin.
+func (h *Histogram) Widths() SliceFloat64 {
+	widths := make(SliceFloat64, len(h.Counts))
+	for i := range widths {
+		widths[i] = h.Edges[i+1] - h.Edges[i]
+	}
+
+	return widths
+}
+
+// Densities will return the probability density of every bin, so
+// that the densities multiplied by the bin widths sum to 1.
+// Underflow and overflow values are not included.
+func (h *Histogram) Densities() SliceFloat64 {
+	densities := make(SliceFloat64, len(h.Counts))
+	total := float64(h.Total())
+	if total == 0 {
+		return densities
+	}
+
+	for i, width := range h.Widths() {
+		if width > 0 {
+			densities[i] = float64(h.Counts[i]) / (total * width)
+		}
+	}
+
+	return densities
+}
+
+// Cumulative will return the running total of the counts,
+// where the i-th value is the number of values in bins 0..i.
+func (h *Histogram) Cumulative() SliceInt {
+	cumulative := make(SliceInt, len(h.Counts))
+	var total int
+	for i, count := range h.Counts {
+		total += count
+		cumulative[i] = total
+	}
+
+	return cumulative
+}
+
+// Merge will add the counts of another histogram with
+// identical edges into this histogram. A nil histogram, which
+// has no edges, cannot be merged.
+func (h *Histogram) Merge(other *Histogram) error {
+	if other == nil || !h.Edges.EqualToSlice(other.Edges) || len(h.Counts) != len(other.Counts) {
+		return ErrHistogramEdges
+	}
+
+	for i, count := range other.Counts {
+		h.Counts[i] += count
+	}
+	h.Underflow += other.Underflow
+	h.Overflow += other.Overflow
+	h.NaNCount += other.NaNCount
+
+	return nil
+}
+
+// Quantile will estimate the q-th quantile of the values inside
+// the edges, assuming the values are spread uniformly within each bin.
+// An empty histogram or a NaN q returns NaN.
+func (h *Histogram) Quantile(q float64) float64 {
+	total := h.Total()
+	if total == 0 || math.IsNaN(q) {
+		return math.NaN()
+	}
+
+	q = math.Max(0, math.Min(1, q))
+	target := q * float64(total)
+
+	var seen float64
+	for i, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+
+		next := seen + float64(count)
+		if next >= target {
+			fraction := (target - seen) / float64(count)
+			return h.Edges[i] + fraction*(h.Edges[i+1]-h.Edges[i])
+		}
+		seen = next
+	}
+
+	return h.Edges[len(h.Edges)-1]
+}
+
+// Percentile will estimate the p-th percentile, where p is in [0,100],
+// of the values inside the edges.
+func (h *Histogram) Percentile(p float64) float64 {
+	return h.Quantile(p / 100)
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHistogramBins(t *testing.T) {
+	h := NewHistogram(SliceFloat64{2, 0, 1})
+	h.AddSlice(SliceFloat64{-1, 0, 0.5, 1, 2, 3, math.NaN()})
+
+	if !h.Counts.EqualOrdered(SliceInt{2, 2}) {
+		t.Errorf("Counts = %v, want [2 2]", h.Counts)
+	}
+	if h.Underflow != 1 || h.Overflow != 1 || h.NaNCount != 1 {
+		t.Errorf("Underflow, Overflow, NaNCount = %d, %d, %d, want 1, 1, 1", h.Underflow, h.Overflow, h.NaNCount)
+	}
+	if !h.Cumulative().EqualOrdered(SliceInt{2, 4}) {
+		t.Errorf("Cumulative() = %v, want [2 4]", h.Cumulative())
+	}
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := NewHistogram(EqualWidthEdges(0, 10, 10))
+	for i := 0; i < 10; i++ {
+		h.Add(float64(i) + 0.5)
+	}
+
+	for q, want := range map[float64]float64{0: 0, 0.25: 2.5, 0.5: 5, 1: 10, -1: 0, 2: 10} {
+		if got := h.Quantile(q); math.Abs(got-want) > 1e-9 {
+			t.Errorf("Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+	if got := h.Quantile(math.NaN()); !math.IsNaN(got) {
+		t.Errorf("Quantile(NaN) = %v, want NaN", got)
+	}
+	if got := NewHistogram(EqualWidthEdges(0, 1, 1)).Quantile(0.5); !math.IsNaN(got) {
+		t.Errorf("Quantile(0.5) of an empty histogram = %v, want NaN", got)
+	}
+}
+
+func TestHistogramMerge(t *testing.T) {
+	edges := EqualWidthEdges(0, 4, 4)
+	a, b := NewHistogram(edges), NewHistogram(edges)
+	a.AddSlice(SliceFloat64{0, 1, 5})
+	b.AddSlice(SliceFloat64{1, 3, -1, math.NaN()})
+
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	if !a.Counts.EqualOrdered(SliceInt{1, 2, 0, 1}) || a.Underflow != 1 || a.Overflow != 1 || a.NaNCount != 1 {
+		t.Errorf("Merge() = %+v", a)
+	}
+
+	if err := a.Merge(nil); err != ErrHistogramEdges {
+		t.Errorf("Merge(nil) returned %v, want ErrHistogramEdges", err)
+	}
+	if err := a.Merge(NewHistogram(EqualWidthEdges(0, 4, 2))); err != ErrHistogramEdges {
+		t.Errorf("Merge() of different edges returned %v, want ErrHistogramEdges", err)
+	}
+}
+
+func TestHistogramEdgesBinCap(t *testing.T) {
+	// a single far outlier makes the range much larger than
+	// the IQR, which would otherwise ask for ~10^10 bins.
+	s := make(SliceFloat64, 1000)
+	for i := range s {
+		s[i] = float64(i%10) / 10
+	}
+	s[0] = 1e9
+	if bins := len(s.HistogramEdges(BinFreedmanDiaconis, 0)) - 1; bins != MaxHistogramBins {
+		t.Errorf("BinFreedmanDiaconis chose %d bins, want %d", bins, MaxHistogramBins)
+	}
+
+	// the standard deviation grows with the outliers, so Scott
+	// only asks for too many bins with many values: two outliers
+	// among 10^6 zeros ask for ~40000 bins.
+	s = make(SliceFloat64, 1000000)
+	s[0], s[1] = -1, 1
+	if bins := len(s.HistogramEdges(BinScott, 0)) - 1; bins != MaxHistogramBins {
+		t.Errorf("BinScott chose %d bins, want %d", bins, MaxHistogramBins)
+	}
+}
+
+func TestHistogramEdgesSturgesFallback(t *testing.T) {
+	// 1000 values give ceil(log2(1000)) + 1 = 11 Sturges bins.
+	same := make(SliceFloat64, 1000)
+	for i := range same {
+		same[i] = 3
+	}
+	same[0] = math.NaN()
+	same[1] = math.Inf(1)
+	same[2] = 3
+
+	for _, strategy := range []BinningStrategy{BinSturges, BinScott, BinFreedmanDiaconis} {
+		edges := same.HistogramEdges(strategy, 0)
+		if bins := len(edges) - 1; bins != 11 {
+			t.Errorf("strategy %d chose %d bins, want 11", strategy, bins)
+		}
+		if edges[0] != 2.5 || edges[len(edges)-1] != 3.5 {
+			t.Errorf("strategy %d chose edges from %v to %v, want 2.5 to 3.5", strategy, edges[0], edges[len(edges)-1])
+		}
+	}
+
+	// an IQR of 0 with some spread falls back to Sturges
+	// for Freedman-Diaconis, but not for Scott.
+	mostly := make(SliceFloat64, 1000)
+	mostly[0] = 1
+	if bins := len(mostly.HistogramEdges(BinFreedmanDiaconis, 0)) - 1; bins != 11 {
+		t.Errorf("BinFreedmanDiaconis chose %d bins, want 11", bins)
+	}
+	if bins := len(mostly.HistogramEdges(BinScott, 0)) - 1; bins == 11 {
+		t.Errorf("BinScott fell back to Sturges with a spread")
+	}
+}
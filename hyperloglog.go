@@ -0,0 +1,322 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+	"sort"
+)
+
+var (
+	ErrHyperLogLogEncoding = errors.New("invalid hyperloglog encoding")
+)
+
+const (
+	// MinHyperLogLogPrecision and MaxHyperLogLogPrecision
+	// bound the precision of a HyperLogLog.
+	MinHyperLogLogPrecision = 4
+	MaxHyperLogLogPrecision = 18
+
+	// DefaultHyperLogLogPrecision is the precision of the zero HyperLogLog.
+	DefaultHyperLogLogPrecision = 14
+
+	// hllSparsePrecision is the precision of the sparse representation.
+	hllSparsePrecision = 25
+	hllVersion         = 1
+)
+
+// HyperLogLog estimates the number of distinct values in a stream
+// using the HyperLogLog++ algorithm: 64-bit hashes and a sparse
+// representation with a higher precision while the cardinality is
+// small. In place of the empirical bias correction tables of
+// HyperLogLog++, linear counting is used while the raw estimate is
+// below 2.5 times the number of registers.
+//
+// A dense HyperLogLog of precision p uses 2^p bytes and has a
+// relative standard error of about 1.04/sqrt(2^p).
+//
+// The zero HyperLogLog is empty and ready to use
+// with a precision of DefaultHyperLogLogPrecision.
+type HyperLogLog struct {
+	precision uint8
+	sparse    map[uint32]uint8
+	registers []uint8
+}
+
+// NewHyperLogLog will return an empty HyperLogLog with the supplied
+// precision, which is clamped to [MinHyperLogLogPrecision, MaxHyperLogLogPrecision].
+func NewHyperLogLog(precision int) *HyperLogLog {
+	if precision < MinHyperLogLogPrecision {
+		precision = MinHyperLogLogPrecision
+	}
+	if precision > MaxHyperLogLogPrecision {
+		precision = MaxHyperLogLogPrecision
+	}
+
+	return &HyperLogLog{
+		precision: uint8(precision),
+		sparse:    make(map[uint32]uint8),
+	}
+}
+
+// Precision will return the precision of the HyperLogLog.
+func (h *HyperLogLog) Precision() int {
+	h.init()
+	return int(h.precision)
+}
+
+// Add will add a string value.
+func (h *HyperLogLog) Add(value string) {
+	h.addHash(hashString(value, 0))
+}
+
+// AddInt64 will add an int64 value.
+func (h *HyperLogLog) AddInt64(value int64) {
+	h.addHash(hashUint64(uint64(value), 0))
+}
+
+// AddSlice will add every value of the slice.
+func (h *HyperLogLog) AddSlice(s SliceString) {
+	for _, v := range s {
+		h.Add(v)
+	}
+}
+
+// AddSliceInt64 will add every value of the slice.
+func (h *HyperLogLog) AddSliceInt64(s SliceInt64) {
+	for _, v := range s {
+		h.AddInt64(v)
+	}
+}
+
+// Count will return the estimated number of distinct values added.
+func (h *HyperLogLog) Count() uint64 {
+	if h.registers == nil {
+		m := float64(uint64(1) << hllSparsePrecision)
+		return uint64(math.Round(m * math.Log(m/(m-float64(len(h.sparse))))))
+	}
+
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros int
+	for _, r := range h.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+
+	alpha := 0.7213 / (1 + 1.079/m)
+	estimate := alpha * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+
+	return uint64(math.Round(estimate))
+}
+
+// Merge will combine the values added to another HyperLogLog
+// of the same precision into this HyperLogLog.
+func (h *HyperLogLog) Merge(other *HyperLogLog) error {
+	h.init()
+	other.init()
+	if h.precision != other.precision {
+		return ErrSketchDimensions
+	}
+
+	if h.registers == nil && other.registers == nil {
+		for idx, rho := range other.sparse {
+			h.setSparse(idx, rho)
+		}
+		h.maybeDensify()
+		return nil
+	}
+
+	h.densify()
+	if other.registers == nil {
+		for idx, rho := range other.sparse {
+			h.setDenseFromSparse(idx, rho)
+		}
+		return nil
+	}
+
+	for i, r := range other.registers {
+		if r > h.registers[i] {
+			h.registers[i] = r
+		}
+	}
+
+	return nil
+}
+
+// MarshalBinary will encode the HyperLogLog into a stable
+// big-endian binary format.
+func (h *HyperLogLog) MarshalBinary() ([]byte, error) {
+	h.init()
+	if h.registers != nil {
+		data := make([]byte, 0, 3+len(h.registers))
+		data = append(data, hllVersion, h.precision, 1)
+		return append(data, h.registers...), nil
+	}
+
+	indices := make([]uint32, 0, len(h.sparse))
+	for idx := range h.sparse {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+
+	data := make([]byte, 0, 7+5*len(indices))
+	data = append(data, hllVersion, h.precision, 0)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(indices)))
+	for _, idx := range indices {
+		data = binary.BigEndian.AppendUint32(data, idx)
+		data = append(data, h.sparse[idx])
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary will decode a HyperLogLog encoded by MarshalBinary,
+// replacing the contents of this HyperLogLog.
+func (h *HyperLogLog) UnmarshalBinary(data []byte) error {
+	if len(data) < 3 || data[0] != hllVersion {
+		return ErrHyperLogLogEncoding
+	}
+
+	precision := data[1]
+	if precision < MinHyperLogLogPrecision || precision > MaxHyperLogLogPrecision {
+		return ErrHyperLogLogEncoding
+	}
+
+	decoded := HyperLogLog{precision: precision}
+	switch data[2] {
+	case 1:
+		if len(data) != 3+1<<precision {
+			return ErrHyperLogLogEncoding
+		}
+		decoded.registers = append([]uint8(nil), data[3:]...)
+	case 0:
+		if len(data) < 7 {
+			return ErrHyperLogLogEncoding
+		}
+		n := int(binary.BigEndian.Uint32(data[3:]))
+		if len(data) != 7+5*n {
+			return ErrHyperLogLogEncoding
+		}
+		decoded.sparse = make(map[uint32]uint8, n)
+		for i := 0; i < n; i++ {
+			offset := 7 + 5*i
+			idx := binary.BigEndian.Uint32(data[offset:])
+			if idx >= 1<<hllSparsePrecision {
+				return ErrHyperLogLogEncoding
+			}
+			decoded.sparse[idx] = data[offset+4]
+		}
+	default:
+		return ErrHyperLogLogEncoding
+	}
+
+	*h = decoded
+	return nil
+}
+
+// addHash will record a 64-bit hash.
+func (h *HyperLogLog) addHash(hash uint64) {
+	h.init()
+	if h.registers != nil {
+		p := h.precision
+		idx := hash >> (64 - p)
+		if rho := rank(hash<<p, 64-p); rho > h.registers[idx] {
+			h.registers[idx] = rho
+		}
+		return
+	}
+
+	idx := uint32(hash >> (64 - hllSparsePrecision))
+	h.setSparse(idx, rank(hash<<hllSparsePrecision, 64-hllSparsePrecision))
+	h.maybeDensify()
+}
+
+// init will make the zero HyperLogLog ready to use.
+func (h *HyperLogLog) init() {
+	if h.precision == 0 {
+		h.precision = DefaultHyperLogLogPrecision
+	}
+	if h.sparse == nil && h.registers == nil {
+		h.sparse = make(map[uint32]uint8)
+	}
+}
+
+// setSparse will raise the sparse register idx to rho.
+func (h *HyperLogLog) setSparse(idx uint32, rho uint8) {
+	if rho > h.sparse[idx] {
+		h.sparse[idx] = rho
+	}
+}
+
+// maybeDensify will switch to the dense representation once the
+// sparse representation is no longer smaller.
+func (h *HyperLogLog) maybeDensify() {
+	if len(h.sparse) > (1<<h.precision)/4 {
+		h.densify()
+	}
+}
+
+// densify will convert the sparse registers into dense registers.
+func (h *HyperLogLog) densify() {
+	if h.registers != nil {
+		return
+	}
+
+	h.registers = make([]uint8, 1<<h.precision)
+	for idx, rho := range h.sparse {
+		h.setDenseFromSparse(idx, rho)
+	}
+	h.sparse = nil
+}
+
+// setDenseFromSparse will fold a sparse register into its dense register.
+func (h *HyperLogLog) setDenseFromSparse(idx uint32, rho uint8) {
+	extra := hllSparsePrecision - h.precision
+	dense := idx >> extra
+
+	// the bits of the sparse index below the dense index are
+	// the leading bits of the dense register's remaining hash.
+	if middle := idx & (1<<extra - 1); middle != 0 {
+		rho = uint8(bits.LeadingZeros32(middle)-(32-int(extra))) + 1
+	} else {
+		rho += extra
+	}
+
+	if rho > h.registers[dense] {
+		h.registers[dense] = rho
+	}
+}
+
+// rank will return the position of the first set bit of
+// the width most significant bits of w, or width+1 if none are set.
+func rank(w uint64, width uint8) uint8 {
+	r := uint8(bits.LeadingZeros64(w)) + 1
+	if r > width+1 {
+		return width + 1
+	}
+
+	return r
+}
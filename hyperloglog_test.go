@@ -0,0 +1,51 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHyperLogLogZeroValue(t *testing.T) {
+	var empty HyperLogLog
+	if count := empty.Count(); count != 0 {
+		t.Errorf("Count() of the zero HyperLogLog = %d, want 0", count)
+	}
+
+	var h HyperLogLog
+	for i := 0; i < 100000; i++ {
+		h.AddInt64(int64(i))
+	}
+	if h.Precision() != DefaultHyperLogLogPrecision {
+		t.Errorf("Precision() = %d, want %d", h.Precision(), DefaultHyperLogLogPrecision)
+	}
+	if err := math.Abs(float64(h.Count())-100000) / 100000; err > 0.03 {
+		t.Errorf("Count() = %d, want about 100000", h.Count())
+	}
+
+	if err := h.Merge(&empty); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := empty.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded HyperLogLog
+	if err := decoded.UnmarshalBinary(data); err != nil || decoded.Count() != 0 {
+		t.Errorf("decoding the zero HyperLogLog = %d, %v, want 0, nil", decoded.Count(), err)
+	}
+}
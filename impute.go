@@ -0,0 +1,210 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"cmp"
+	"math"
+)
+
+// ImputeStrategy is how an imputer fills missing values.
+type ImputeStrategy int
+
+const (
+	// ImputeMean fills with the mean of the values fit on.
+	ImputeMean ImputeStrategy = iota
+	// ImputeMedian fills with the median of the values fit on.
+	ImputeMedian
+	// ImputeMode fills with the most frequent of the values fit on,
+	// preferring the smallest value on ties.
+	ImputeMode
+	// ImputeConstant fills with the imputer's Value.
+	ImputeConstant
+	// ImputeForwardFill fills with the last value before the gap.
+	ImputeForwardFill
+	// ImputeBackFill fills with the first value after the gap.
+	ImputeBackFill
+	// ImputeLinear fills by linear interpolation between
+	// the values on either side of the gap.
+	ImputeLinear
+)
+
+// ImputerFloat64 fills the NaN values of a SliceFloat64. The mean,
+// median and mode strategies learn their fill value from the slice
+// the imputer is fit on, so that the same value can be used to fill
+// any other slice. The other strategies do not need to be fit.
+//
+// Runs of consecutive missing values longer than Limit are left
+// missing; a Limit of 0 means there is no limit. Gaps at the start
+// of a slice cannot be forward filled, gaps at the end cannot be
+// back filled, and neither can be interpolated, so they are also
+// left missing.
+type ImputerFloat64 struct {
+	Strategy ImputeStrategy `json:"strategy"`
+	Value    float64        `json:"value"`
+	Limit    int            `json:"limit"`
+}
+
+// Fit will record the fill value of the mean, median or mode
+// strategies from the non-NaN values of the slice.
+func (m *ImputerFloat64) Fit(s SliceFloat64) {
+	values := s.DropNaN()
+	switch m.Strategy {
+	case ImputeMean:
+		m.Value = values.Avg()
+	case ImputeMedian:
+		m.Value = values.MedianValue()
+	case ImputeMode:
+		m.Value = mode(values)
+	}
+}
+
+// Transform will return a copy of the slice with the missing values
+// filled, and a mask of the indices that were filled.
+func (m *ImputerFloat64) Transform(s SliceFloat64) (SliceFloat64, SliceBool) {
+	filled := s.Copy()
+	mask := impute(filled, m.Strategy, m.Value, m.Limit, func(v float64) bool {
+		return math.IsNaN(v)
+	}, func(start, end int) {
+		from, to := filled[start-1], filled[end]
+		for i := start; i < end; i++ {
+			t := float64(i-start+1) / float64(end-start+1)
+			filled[i] = from + t*(to-from)
+		}
+	})
+
+	return filled, mask
+}
+
+// FitTransform will fit the imputer to the slice and return
+// the filled copy and its mask.
+func (m *ImputerFloat64) FitTransform(s SliceFloat64) (SliceFloat64, SliceBool) {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// ImputerString fills the empty strings of a SliceString. It behaves
+// like ImputerFloat64, except that the mean, median and linear
+// strategies are not defined for strings and leave values missing.
+type ImputerString struct {
+	Strategy ImputeStrategy `json:"strategy"`
+	Value    string         `json:"value"`
+	Limit    int            `json:"limit"`
+}
+
+// Fit will record the fill value of the mode
+// strategy from the non-empty values of the slice.
+func (m *ImputerString) Fit(s SliceString) {
+	if m.Strategy != ImputeMode {
+		return
+	}
+
+	values := make(SliceString, 0, len(s))
+	for _, v := range s {
+		if v != "" {
+			values = append(values, v)
+		}
+	}
+	m.Value = mode(values)
+}
+
+// Transform will return a copy of the slice with the missing values
+// filled, and a mask of the indices that were filled.
+func (m *ImputerString) Transform(s SliceString) (SliceString, SliceBool) {
+	filled := s.Copy()
+	switch m.Strategy {
+	case ImputeMean, ImputeMedian, ImputeLinear:
+		return filled, make(SliceBool, len(s))
+	}
+
+	mask := impute(filled, m.Strategy, m.Value, m.Limit, func(v string) bool {
+		return v == ""
+	}, nil)
+
+	return filled, mask
+}
+
+// FitTransform will fit the imputer to the slice and return
+// the filled copy and its mask.
+func (m *ImputerString) FitTransform(s SliceString) (SliceString, SliceBool) {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// impute will fill each run of missing values of s in place and
+// return a mask of the filled indices. interpolate is called with
+// the bounds of each run that has values on both sides.
+func impute[E any](s []E, strategy ImputeStrategy, value E, limit int, missing func(E) bool, interpolate func(start, end int)) SliceBool {
+	mask := make(SliceBool, len(s))
+	for start := 0; start < len(s); start++ {
+		if !missing(s[start]) {
+			continue
+		}
+
+		end := start
+		for end < len(s) && missing(s[end]) {
+			end++
+		}
+		run := start
+		start = end
+		if limit > 0 && end-run > limit {
+			continue
+		}
+
+		fill := value
+		switch strategy {
+		case ImputeForwardFill:
+			if run == 0 {
+				continue
+			}
+			fill = s[run-1]
+		case ImputeBackFill:
+			if end == len(s) {
+				continue
+			}
+			fill = s[end]
+		case ImputeLinear:
+			if run == 0 || end == len(s) {
+				continue
+			}
+			interpolate(run, end)
+		}
+
+		for i := run; i < end; i++ {
+			if strategy != ImputeLinear {
+				s[i] = fill
+			}
+			mask[i] = true
+		}
+	}
+
+	return mask
+}
+
+// mode will return the most frequent value of the
+// slice, preferring the smallest value on ties.
+func mode[S ~[]E, E cmp.Ordered](s S) (value E) {
+	counts := make(map[E]int, len(s))
+	var best int
+	for _, v := range s {
+		counts[v]++
+		n := counts[v]
+		if n > best || (n == best && v < value) {
+			value, best = v, n
+		}
+	}
+
+	return
+}
@@ -14,8 +14,6 @@
 
 package sam
 
-import "math"
-
 // MapIntFloat64 uses integers as keys
 // and float64s as values.
 type MapIntFloat64 map[int]float64
@@ -23,73 +21,38 @@ type MapIntFloat64 map[int]float64
 // Min will return the integer key with the smallest
 // float64 value.
 func (m MapIntFloat64) Min() (index int) {
-	min := math.MaxFloat64
-	for i, v := range m {
-		if v < min {
-			min = v
-			index = i
-		}
-	}
-
+	index, _ = MinValue(m)
 	return
 }
 
 // MinValue will return the smallest float64 value
 // in the map.
 func (m MapIntFloat64) MinValue() float64 {
-	min := math.MaxFloat64
-	for _, v := range m {
-		if v < min {
-			min = v
-		}
-	}
-
+	_, min := MinValue(m)
 	return min
 }
 
-
 // Max will return the integer key with the
 // largest float64 value.
 func (m MapIntFloat64) Max() (index int) {
-	max := math.SmallestNonzeroFloat64
-	for i, v := range m {
-		if v > max {
-			max = v
-			index = i
-		}
-	}
-
+	index, _ = MaxValue(m)
 	return
 }
 
 // MaxValue will return the largest float64 value
 // in the map.
 func (m MapIntFloat64) MaxValue() float64 {
-	max := math.SmallestNonzeroFloat64
-	for _, v := range m {
-		if v > max {
-			max = v
-		}
-	}
-
+	_, max := MaxValue(m)
 	return max
 }
 
 // MaxN will return a MapIntFloat64 containing the N largest
 // key/value pairs by value.
 func (m MapIntFloat64) MaxN(n int) MapIntFloat64 {
-	maxN := make(MapIntFloat64)
-
-	for i, v := range m {
-		if len(maxN) < n {
-			maxN[i] = v
-		} else {
-			minIndex := maxN.Min()
-			if v > maxN[minIndex] {
-				delete(maxN, minIndex)
-				maxN[i] = v
-			}
-		}
+	keys, values := m.TopKByValue(n)
+	maxN := make(MapIntFloat64, len(keys))
+	for i, key := range keys {
+		maxN[key] = values[i]
 	}
 
 	return maxN
@@ -14,8 +14,6 @@
 
 package sam
 
-import "math"
-
 // MapStringFloat64 can be built by simply calling
 // make(MapStringFloat64).
 type MapStringFloat64 map[string]float64
@@ -36,33 +34,21 @@ func (m MapStringFloat64) Contains(s string) bool {
 // Values will return the slice of all values
 // found in the map.
 func (m MapStringFloat64) Values() (values SliceFloat64) {
-	for _, v := range m {
-		values = append(values, v)
-	}
-
+	values = Values(m)
 	return
 }
 
 // Keys will return the slice of all keys
 // found in the map.
 func (m MapStringFloat64) Keys() (keys SliceString) {
-	for k := range m {
-		keys = append(keys, k)
-	}
-
+	keys = Keys(m)
 	return
 }
 
 // KeysAndValues will return two ordered sets of keys and values that are aligned
 // by slice index.
 func (m MapStringFloat64) KeysAndValues() (keys SliceString, values SliceFloat64) {
-	keys = make(SliceString, 0, len(m))
-	values = make(SliceFloat64, 0, len(m))
-	for k, v := range m {
-		keys = append(keys, k)
-		values = append(values, v)
-	}
-
+	keys, values = KeysAndValues(m)
 	return
 }
 
@@ -70,30 +56,12 @@ func (m MapStringFloat64) KeysAndValues() (keys SliceString, values SliceFloat64
 // The key is the first result returned. And the value
 // is the second result returned.
 func (m MapStringFloat64) MaxValue() (key string, value float64) {
-	max := math.SmallestNonzeroFloat64
-	for k, v := range m {
-		if v > max {
-			max = v
-			key = k
-			value = v
-		}
-	}
-
-	return
+	return MaxValue(m)
 }
 
 // MinValue will return the smallest value and it's key.
 // The key is the first result returned. And the value
 // is the second result returned.
 func (m MapStringFloat64) MinValue() (key string, value float64) {
-	min := math.MaxFloat64
-	for k, v := range m {
-		if v < min {
-			min = v
-			key = k
-			value = v
-		}
-	}
-
-	return
+	return MinValue(m)
 }
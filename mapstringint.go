@@ -14,8 +14,6 @@
 
 package sam
 
-import "math"
-
 // MapStringInt can be built by simply calling
 // make(MapStringInt).
 type MapStringInt map[string]int
@@ -36,33 +34,21 @@ func (m MapStringInt) Contains(s string) bool {
 // Values will return the slice of all values
 // found in the map.
 func (m MapStringInt) Values() (values SliceInt) {
-	for _, v := range m {
-		values = append(values, v)
-	}
-
+	values = Values(m)
 	return
 }
 
 // Keys will return the slice of all keys
 // found in the map.
 func (m MapStringInt) Keys() (keys SliceString) {
-	for k := range m {
-		keys = append(keys, k)
-	}
-
+	keys = Keys(m)
 	return
 }
 
 // KeysAndValues will return two ordered sets of keys and values that are aligned
 // by slice index.
 func (m MapStringInt) KeysAndValues() (keys SliceString, values SliceInt) {
-	keys = make(SliceString, 0, len(m))
-	values = make(SliceInt, 0, len(m))
-	for k, v := range m {
-		keys = append(keys, k)
-		values = append(values, v)
-	}
-
+	keys, values = KeysAndValues(m)
 	return
 }
 
@@ -70,30 +56,12 @@ func (m MapStringInt) KeysAndValues() (keys SliceString, values SliceInt) {
 // The key is the first result returned. And the value
 // is the second result returned.
 func (m MapStringInt) MaxValue() (key string, value int) {
-	max := math.MinInt64
-	for k, v := range m {
-		if v > max {
-			max = v
-			key = k
-			value = v
-		}
-	}
-
-	return
+	return MaxValue(m)
 }
 
 // MinValue will return the smallest value and it's key.
 // The key is the first result returned. And the value
 // is the second result returned.
 func (m MapStringInt) MinValue() (key string, value int) {
-	min := math.MaxInt64
-	for k, v := range m {
-		if v < min {
-			min = v
-			key = k
-			value = v
-		}
-	}
-
-	return
+	return MinValue(m)
 }
@@ -0,0 +1,245 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	ErrNaN = errors.New("slice contains NaN")
+	ErrInf = errors.New("slice contains an infinite value")
+)
+
+// NonFinitePolicy is how a statistic treats NaN or infinite values.
+type NonFinitePolicy int
+
+const (
+	// NonFinitePropagate makes any NaN value produce a NaN result,
+	// and keeps infinite values as ordinary values.
+	NonFinitePropagate NonFinitePolicy = iota
+	// NonFiniteSkip ignores the values.
+	NonFiniteSkip
+	// NonFiniteError makes the statistic return ErrNaN or ErrInf.
+	NonFiniteError
+)
+
+// PolicyFloat64 is a SliceFloat64 whose statistics follow
+// explicit policies for NaN and infinite values.
+// The zero policies propagate both.
+//
+// The bare SliceFloat64 methods are not consistent about NaN:
+// Min, Max, Range, Mode, MedianValue, Quantile and IQR ignore
+// NaN values while the others return NaN. Every statistic of a
+// PolicyFloat64 follows the same policy instead, and returns
+// ErrEmpty when no values remain once the policies are applied.
+type PolicyFloat64 struct {
+	Values SliceFloat64
+	NaN    NonFinitePolicy
+	Inf    NonFinitePolicy
+}
+
+// WithPolicy will return the slice wrapped with the supplied
+// NaN and infinity policies, which can be kept for repeated
+// use or used for a single call:
+//
+//	avg, err := s.WithPolicy(NonFiniteSkip, NonFiniteError).Avg()
+func (s SliceFloat64) WithPolicy(nan, inf NonFinitePolicy) PolicyFloat64 {
+	return PolicyFloat64{Values: s, NaN: nan, Inf: inf}
+}
+
+// NaNCount will return the number of NaN values in the slice.
+func (s SliceFloat64) NaNCount() (count int) {
+	for _, v := range s {
+		if math.IsNaN(v) {
+			count++
+		}
+	}
+
+	return
+}
+
+// DropNaN will return a copy of the slice without any NaN values.
+func (s SliceFloat64) DropNaN() SliceFloat64 {
+	dropped := make(SliceFloat64, 0, len(s))
+	for _, v := range s {
+		if !math.IsNaN(v) {
+			dropped = append(dropped, v)
+		}
+	}
+
+	return dropped
+}
+
+// FillNaN will return a copy of the slice with every
+// NaN value replaced by the supplied value.
+func (s SliceFloat64) FillNaN(value float64) SliceFloat64 {
+	filled := s.Copy()
+	for i, v := range filled {
+		if math.IsNaN(v) {
+			filled[i] = value
+		}
+	}
+
+	return filled
+}
+
+// Sum will return the sum of the values.
+func (p PolicyFloat64) Sum() (float64, error) {
+	return p.apply(SliceFloat64.Sum)
+}
+
+// Avg will return the average of the values.
+func (p PolicyFloat64) Avg() (float64, error) {
+	return p.apply(SliceFloat64.Avg)
+}
+
+// Min will return the smallest value.
+func (p PolicyFloat64) Min() (float64, error) {
+	return p.apply(SliceFloat64.Min)
+}
+
+// Max will return the largest value.
+func (p PolicyFloat64) Max() (float64, error) {
+	return p.apply(SliceFloat64.Max)
+}
+
+// Median will return the median of the values.
+func (p PolicyFloat64) Median() (float64, error) {
+	return p.apply(SliceFloat64.MedianValue)
+}
+
+// Quantile will return the q-th quantile of the values.
+func (p PolicyFloat64) Quantile(q float64, method QuantileMethod) (float64, error) {
+	return p.apply(func(s SliceFloat64) float64 {
+		return s.Quantile(q, method)
+	})
+}
+
+// Variance will return the population variance of the values.
+func (p PolicyFloat64) Variance() (float64, error) {
+	return p.apply(SliceFloat64.Variance)
+}
+
+// SampleVariance will return the sample variance of the values.
+func (p PolicyFloat64) SampleVariance() (float64, error) {
+	return p.apply(SliceFloat64.SampleVariance)
+}
+
+// StdDev will return the population standard deviation of the values.
+func (p PolicyFloat64) StdDev() (float64, error) {
+	return p.apply(SliceFloat64.StdDev)
+}
+
+// SampleStdDev will return the sample standard deviation of the values.
+func (p PolicyFloat64) SampleStdDev() (float64, error) {
+	return p.apply(SliceFloat64.SampleStdDev)
+}
+
+// Skewness will return the population skewness of the values.
+func (p PolicyFloat64) Skewness() (float64, error) {
+	return p.apply(SliceFloat64.Skewness)
+}
+
+// Kurtosis will return the population excess kurtosis of the values.
+func (p PolicyFloat64) Kurtosis() (float64, error) {
+	return p.apply(SliceFloat64.Kurtosis)
+}
+
+// CoefficientOfVariation will return the ratio of the population
+// standard deviation to the mean of the values.
+func (p PolicyFloat64) CoefficientOfVariation() (float64, error) {
+	return p.apply(SliceFloat64.CoefficientOfVariation)
+}
+
+// IQR will return the interquartile range of the values.
+func (p PolicyFloat64) IQR() (float64, error) {
+	return p.apply(SliceFloat64.IQR)
+}
+
+// Range will return the difference between the largest
+// and the smallest value.
+func (p PolicyFloat64) Range() (float64, error) {
+	return p.apply(SliceFloat64.Range)
+}
+
+// Mode will return the most frequent value.
+func (p PolicyFloat64) Mode() (float64, error) {
+	return p.apply(SliceFloat64.Mode)
+}
+
+// AverageDeviation will return the mean absolute deviation
+// of the values from their mean.
+func (p PolicyFloat64) AverageDeviation() (float64, error) {
+	return p.apply(SliceFloat64.AverageDeviation)
+}
+
+// apply will compute the statistic over the values
+// that remain once the policies have been applied.
+func (p PolicyFloat64) apply(statistic func(SliceFloat64) float64) (float64, error) {
+	values, propagated, err := p.clean()
+	if err != nil || propagated {
+		return math.NaN(), err
+	}
+	if len(values) == 0 {
+		return math.NaN(), ErrEmpty
+	}
+
+	return statistic(values), nil
+}
+
+// clean will return the values the policies allow, and whether
+// a NaN value is propagated. The slice is only copied if a value
+// needs to be skipped.
+func (p PolicyFloat64) clean() (values SliceFloat64, propagated bool, err error) {
+	for i, v := range p.Values {
+		var policy NonFinitePolicy
+		var policyErr error
+		switch {
+		case math.IsNaN(v):
+			policy, policyErr = p.NaN, ErrNaN
+		case math.IsInf(v, 0):
+			policy, policyErr = p.Inf, ErrInf
+		default:
+			if values != nil {
+				values = append(values, v)
+			}
+			continue
+		}
+
+		switch policy {
+		case NonFiniteError:
+			return nil, false, policyErr
+		case NonFiniteSkip:
+			if values == nil {
+				values = append(make(SliceFloat64, 0, len(p.Values)), p.Values[:i]...)
+			}
+		default:
+			if policyErr == ErrNaN {
+				return nil, true, nil
+			}
+			if values != nil {
+				values = append(values, v)
+			}
+		}
+	}
+
+	if values == nil {
+		return p.Values, false, nil
+	}
+
+	return values, false, nil
+}
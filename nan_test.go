@@ -0,0 +1,81 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPolicyFloat64SkipAllNaN(t *testing.T) {
+	nan := math.NaN()
+	p := SliceFloat64{nan, nan}.WithPolicy(NonFiniteSkip, NonFiniteSkip)
+
+	statistics := map[string]func() (float64, error){
+		"Sum":                    p.Sum,
+		"Avg":                    p.Avg,
+		"Min":                    p.Min,
+		"Max":                    p.Max,
+		"Median":                 p.Median,
+		"Variance":               p.Variance,
+		"Skewness":               p.Skewness,
+		"Kurtosis":               p.Kurtosis,
+		"CoefficientOfVariation": p.CoefficientOfVariation,
+		"IQR":                    p.IQR,
+		"Range":                  p.Range,
+		"Mode":                   p.Mode,
+		"AverageDeviation":       p.AverageDeviation,
+	}
+	for name, statistic := range statistics {
+		if _, err := statistic(); err != ErrEmpty {
+			t.Errorf("%s() of only skipped values returned %v, want ErrEmpty", name, err)
+		}
+	}
+}
+
+func TestPolicyFloat64Propagate(t *testing.T) {
+	p := SliceFloat64{1, math.NaN(), 3}.WithPolicy(NonFinitePropagate, NonFinitePropagate)
+
+	// Min, Range and Mode ignore NaN values on a bare
+	// SliceFloat64, but propagate them under the policy.
+	for name, statistic := range map[string]func() (float64, error){
+		"Min":   p.Min,
+		"Range": p.Range,
+		"Mode":  p.Mode,
+	} {
+		if v, err := statistic(); err != nil || !math.IsNaN(v) {
+			t.Errorf("%s() = %v, %v, want NaN, nil", name, v, err)
+		}
+	}
+
+	skipped := SliceFloat64{1, math.NaN(), 3, 3}.WithPolicy(NonFiniteSkip, NonFinitePropagate)
+	if v, err := skipped.Range(); err != nil || v != 2 {
+		t.Errorf("Range() = %v, %v, want 2, nil", v, err)
+	}
+	if v, err := skipped.Mode(); err != nil || v != 3 {
+		t.Errorf("Mode() = %v, %v, want 3, nil", v, err)
+	}
+}
+
+func TestRangeAndModeIgnoreNaN(t *testing.T) {
+	nan := math.NaN()
+	s := SliceFloat64{-5, nan, -2, nan, -2}
+	if v := s.Range(); v != 3 {
+		t.Errorf("Range() = %v, want 3", v)
+	}
+	if v := s.Mode(); v != -2 {
+		t.Errorf("Mode() = %v, want -2", v)
+	}
+}
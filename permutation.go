@@ -0,0 +1,181 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrInvalidPermutation = errors.New("invalid permutation")
+)
+
+// Permutation is a reordering of the positions of a slice.
+// Position i of a reordered slice holds the value found at
+// position p[i] of the original slice, so the Permutation
+// returned by Argsort lists the indices of the values in
+// sorted order.
+type Permutation []int
+
+// NewPermutation will return the identity permutation of length n,
+// which leaves every value of a slice in place.
+func NewPermutation(n int) Permutation {
+	p := make(Permutation, n)
+	for i := range p {
+		p[i] = i
+	}
+
+	return p
+}
+
+// Argsort will return the permutation that stably sorts the slice,
+// with any NaN values at the end whichever the order.
+func (s SliceFloat64) Argsort(ascending bool) Permutation {
+	return argsort(len(s), ascending, s.Less, func(i int) bool {
+		return isNaN(s[i])
+	})
+}
+
+// Argsort will return the permutation that stably sorts the slice.
+func (s SliceInt) Argsort(ascending bool) Permutation {
+	return argsort(len(s), ascending, func(i, j int) bool {
+		return s[i] < s[j]
+	}, nil)
+}
+
+// Argsort will return the permutation that stably sorts the slice.
+func (s SliceInt64) Argsort(ascending bool) Permutation {
+	return argsort(len(s), ascending, func(i, j int) bool {
+		return s[i] < s[j]
+	}, nil)
+}
+
+// Argsort will return the permutation that stably sorts
+// the slice, ordering the strings as Less does.
+func (s SliceString) Argsort(ascending bool) Permutation {
+	return argsort(len(s), ascending, s.Less, nil)
+}
+
+// Valid will check if the permutation contains
+// every index from 0 to len(p)-1 exactly once.
+func (p Permutation) Valid() bool {
+	seen := make(SliceBool, len(p))
+	for _, index := range p {
+		if index < 0 || index >= len(p) || seen[index] {
+			return false
+		}
+		seen[index] = true
+	}
+
+	return true
+}
+
+// Apply will reorder each of the slices in place, through
+// their Get and Set methods, so that aligned columns stay aligned.
+// Nothing is reordered if the permutation is invalid, which returns
+// ErrInvalidPermutation, or if any slice is not the same length as
+// the permutation, which returns ErrLengthMismatch.
+func (p Permutation) Apply(columns ...Slice) error {
+	if !p.Valid() {
+		return ErrInvalidPermutation
+	}
+	for _, s := range columns {
+		if s.Len() != len(p) {
+			return ErrLengthMismatch
+		}
+	}
+
+	done := make(SliceBool, len(p))
+	for _, s := range columns {
+		for i := range done {
+			done[i] = false
+		}
+
+		// follow each cycle of the permutation, shifting
+		// every value of the cycle into its new position.
+		for start := range p {
+			if done[start] {
+				continue
+			}
+
+			first := s.Get(start)
+			i := start
+			for p[i] != start {
+				s.Set(i, s.Get(p[i]))
+				done[i] = true
+				i = p[i]
+			}
+			s.Set(i, first)
+			done[i] = true
+		}
+	}
+
+	return nil
+}
+
+// Inverse will return the permutation that undoes this permutation,
+// or ErrInvalidPermutation if the permutation is invalid.
+func (p Permutation) Inverse() (Permutation, error) {
+	if !p.Valid() {
+		return nil, ErrInvalidPermutation
+	}
+
+	inverse := make(Permutation, len(p))
+	for i, index := range p {
+		inverse[index] = i
+	}
+
+	return inverse, nil
+}
+
+// Compose will return the single permutation that has the same
+// effect as applying this permutation and then the other. It returns
+// ErrInvalidPermutation if either permutation is invalid, or
+// ErrLengthMismatch if they are not the same length.
+func (p Permutation) Compose(other Permutation) (Permutation, error) {
+	if !p.Valid() || !other.Valid() {
+		return nil, ErrInvalidPermutation
+	}
+	if len(p) != len(other) {
+		return nil, ErrLengthMismatch
+	}
+
+	composed := make(Permutation, len(p))
+	for i, index := range other {
+		composed[i] = p[index]
+	}
+
+	return composed, nil
+}
+
+// argsort will return the permutation that stably sorts n values by
+// less, in ascending or descending order. Values for which last
+// returns true are placed at the end in either order.
+func argsort(n int, ascending bool, less func(i, j int) bool, last func(i int) bool) Permutation {
+	p := NewPermutation(n)
+	sort.SliceStable(p, func(a, b int) bool {
+		i, j := p[a], p[b]
+		if last != nil && (last(i) || last(j)) {
+			return !last(i)
+		}
+		if ascending {
+			return less(i, j)
+		}
+		return less(j, i)
+	})
+
+	return p
+}
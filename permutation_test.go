@@ -0,0 +1,114 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestPermutationApply(t *testing.T) {
+	names := SliceString{"c", "a", "b"}
+	ages := SliceInt{3, 1, 2}
+
+	p := names.Argsort(true)
+	if err := p.Apply(names, ages); err != nil {
+		t.Fatal(err)
+	}
+	if !names.EqualOrdered(SliceString{"a", "b", "c"}) || !ages.EqualOrdered(SliceInt{1, 2, 3}) {
+		t.Errorf("Apply() = %v, %v, want [a b c], [1 2 3]", names, ages)
+	}
+
+	if err := p.Apply(SliceInt{1, 2}); err != ErrLengthMismatch {
+		t.Errorf("Apply() of a shorter slice returned %v, want ErrLengthMismatch", err)
+	}
+	if err := (Permutation{0, 0, 1}).Apply(ages); err != ErrInvalidPermutation {
+		t.Errorf("Apply() of an invalid permutation returned %v, want ErrInvalidPermutation", err)
+	}
+	if !ages.EqualOrdered(SliceInt{1, 2, 3}) {
+		t.Errorf("a failed Apply() reordered the slice to %v", ages)
+	}
+}
+
+func TestPermutationRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, n := range []int{0, 1, 2, 10, 100} {
+		original := make(SliceInt, n)
+		for i := range original {
+			original[i] = r.Int()
+		}
+
+		p, q := Permutation(r.Perm(n)), Permutation(r.Perm(n))
+		inverse, err := p.Inverse()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := original.Copy()
+		if err := p.Apply(s); err != nil {
+			t.Fatal(err)
+		}
+		if err := inverse.Apply(s); err != nil {
+			t.Fatal(err)
+		}
+		if !s.EqualOrdered(original) {
+			t.Errorf("n=%d: Apply() of the Inverse() did not restore the slice", n)
+		}
+
+		// applying p and then q matches applying their composition.
+		composed, err := p.Compose(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sequential, combined := original.Copy(), original.Copy()
+		if err := p.Apply(sequential); err != nil {
+			t.Fatal(err)
+		}
+		if err := q.Apply(sequential); err != nil {
+			t.Fatal(err)
+		}
+		if err := composed.Apply(combined); err != nil {
+			t.Fatal(err)
+		}
+		if !sequential.EqualOrdered(combined) {
+			t.Errorf("n=%d: Apply() of Compose() = %v, want %v", n, combined, sequential)
+		}
+
+		identity, err := p.Compose(inverse)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !SliceInt(identity).EqualOrdered(SliceInt(NewPermutation(n))) {
+			t.Errorf("n=%d: Compose() with the Inverse() = %v, want the identity", n, identity)
+		}
+	}
+}
+
+func TestPermutationInvalid(t *testing.T) {
+	invalid := Permutation{5}
+	if _, err := invalid.Inverse(); err != ErrInvalidPermutation {
+		t.Errorf("Inverse() returned %v, want ErrInvalidPermutation", err)
+	}
+	if _, err := invalid.Compose(NewPermutation(1)); err != ErrInvalidPermutation {
+		t.Errorf("Compose() returned %v, want ErrInvalidPermutation", err)
+	}
+	if _, err := NewPermutation(1).Compose(invalid); err != ErrInvalidPermutation {
+		t.Errorf("Compose() of an invalid other returned %v, want ErrInvalidPermutation", err)
+	}
+	if _, err := NewPermutation(1).Compose(NewPermutation(2)); err != ErrLengthMismatch {
+		t.Errorf("Compose() of different lengths returned %v, want ErrLengthMismatch", err)
+	}
+}
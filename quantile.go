@@ -0,0 +1,167 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"sort"
+)
+
+// QuantileMethod selects how a quantile that falls between
+// two data points is interpolated. With the values sorted and
+// h = (n-1)*q, the methods correspond to the Hyndman–Fan type 7
+// estimator and its discrete variants.
+type QuantileMethod int
+
+const (
+	// QuantileLinear interpolates linearly between the two
+	// closest data points (Hyndman–Fan type 7).
+	QuantileLinear QuantileMethod = iota
+	// QuantileLower takes the data point at floor(h).
+	QuantileLower
+	// QuantileHigher takes the data point at ceil(h).
+	QuantileHigher
+	// QuantileNearest takes the data point closest to h,
+	// rounding halves to the even index.
+	QuantileNearest
+	// QuantileMidpoint averages the data points at floor(h) and ceil(h).
+	QuantileMidpoint
+)
+
+// Quantile will return the q-th quantile of the values in the slice,
+// where q is in [0,1] and is clamped to that range otherwise.
+// A NaN q returns NaN. NaN values are ignored and the slice
+// itself is not reordered. An empty slice returns 0.
+func (s SliceFloat64) Quantile(q float64, method QuantileMethod) float64 {
+	sorted := s.sortedNumbers()
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	return sorted.sortedQuantile(q, method)
+}
+
+// Quantiles will return the quantile of the values in the slice
+// for each of the supplied qs. The slice is only copied and sorted once.
+func (s SliceFloat64) Quantiles(qs []float64, method QuantileMethod) SliceFloat64 {
+	quantiles := make(SliceFloat64, len(qs))
+
+	sorted := s.sortedNumbers()
+	if len(sorted) == 0 {
+		return quantiles
+	}
+
+	for i, q := range qs {
+		quantiles[i] = sorted.sortedQuantile(q, method)
+	}
+
+	return quantiles
+}
+
+// Percentile will return the p-th percentile of the values
+// in the slice, where p is in [0,100].
+func (s SliceFloat64) Percentile(p float64, method QuantileMethod) float64 {
+	return s.Quantile(p/100, method)
+}
+
+// IQR will return the interquartile range (Q3 - Q1)
+// of the values in the slice using linear interpolation.
+// NaN values are ignored.
+func (s SliceFloat64) IQR() float64 {
+	quartiles := s.Quantiles([]float64{0.25, 0.75}, QuantileLinear)
+	return quartiles[1] - quartiles[0]
+}
+
+// Median will sort the slice itself and return the index and
+// value of its middle value, the upper of the two middle values
+// for an even length slice. An empty slice returns 0 and 0.
+//
+// Deprecated: use MedianValue, which does not reorder the slice,
+// ignores NaN values and averages the two middle values.
+func (s SliceFloat64) Median() (index int, value float64) {
+	if len(s) == 0 {
+		return 0, 0
+	}
+	sort.Sort(s)
+
+	index = len(s) / 2
+	value = s[index]
+
+	return
+}
+
+// MedianValue will return the median of the values in the slice.
+// Even length slices return the average of the two middle values.
+// NaN values are ignored and the slice itself is not reordered.
+func (s SliceFloat64) MedianValue() float64 {
+	return s.Quantile(0.5, QuantileLinear)
+}
+
+// sortedNumbers will return a sorted copy of the
+// slice without any NaN values.
+func (s SliceFloat64) sortedNumbers() SliceFloat64 {
+	sorted := make(SliceFloat64, 0, len(s))
+	for _, v := range s {
+		if !math.IsNaN(v) {
+			sorted = append(sorted, v)
+		}
+	}
+	sort.Float64s(sorted)
+
+	return sorted
+}
+
+// sortedQuantile will return the q-th quantile of an
+// already sorted, non-empty slice.
+func (s SliceFloat64) sortedQuantile(q float64, method QuantileMethod) float64 {
+	return quantileAt(len(s), q, method, func(i int) float64 {
+		return s[i]
+	})
+}
+
+// quantileAt will return the q-th quantile of n > 0 ordered values,
+// where at(i) returns the i-th smallest value. A NaN q returns NaN.
+func quantileAt(n int, q float64, method QuantileMethod, at func(int) float64) float64 {
+	if math.IsNaN(q) {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return at(0)
+	}
+	if q >= 1 {
+		return at(n - 1)
+	}
+
+	h := q * float64(n-1)
+	lo := int(math.Floor(h))
+	hi := int(math.Ceil(h))
+
+	switch method {
+	case QuantileLower:
+		return at(lo)
+	case QuantileHigher:
+		return at(hi)
+	case QuantileNearest:
+		return at(int(math.RoundToEven(h)))
+	case QuantileMidpoint:
+		return (at(lo) + at(hi)) / 2
+	default:
+		low := at(lo)
+		if lo == hi {
+			return low
+		}
+		return low + (h-float64(lo))*(at(hi)-low)
+	}
+}
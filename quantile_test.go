@@ -0,0 +1,63 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantileNaN(t *testing.T) {
+	s := SliceFloat64{1, 2, 3, 4}
+	nan := math.NaN()
+
+	for method := QuantileLinear; method <= QuantileMidpoint; method++ {
+		if v := s.Quantile(nan, method); !math.IsNaN(v) {
+			t.Errorf("Quantile(NaN, %d) = %v, want NaN", method, v)
+		}
+	}
+	if v := s.Percentile(nan, QuantileLinear); !math.IsNaN(v) {
+		t.Errorf("Percentile(NaN) = %v, want NaN", v)
+	}
+	if v, err := s.WithPolicy(NonFiniteSkip, NonFiniteSkip).Quantile(nan, QuantileLinear); err != nil || !math.IsNaN(v) {
+		t.Errorf("PolicyFloat64.Quantile(NaN) = %v, %v, want NaN", v, err)
+	}
+
+	rolling := s.RollingQuantile(nan, QuantileLinear, RollingOptions{Window: 2})
+	for i, v := range rolling {
+		if !math.IsNaN(v) {
+			t.Errorf("RollingQuantile(NaN)[%d] = %v, want NaN", i, v)
+		}
+	}
+}
+
+func TestMedian(t *testing.T) {
+	s := SliceFloat64{4, 1, 3, 2}
+
+	if v := s.MedianValue(); v != 2.5 {
+		t.Errorf("MedianValue() = %v, want 2.5", v)
+	}
+	if !s.EqualOrdered(SliceFloat64{4, 1, 3, 2}) {
+		t.Errorf("MedianValue reordered the slice to %v", s)
+	}
+
+	// the deprecated Median keeps its original behaviour.
+	if index, value := s.Median(); index != 2 || value != 3 {
+		t.Errorf("Median() = %d, %v, want 2, 3", index, value)
+	}
+	if !s.EqualOrdered(SliceFloat64{1, 2, 3, 4}) {
+		t.Errorf("Median did not sort the slice: %v", s)
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"sort"
+)
+
+// RollingOptions configures the sliding window used by
+// the Rolling methods of SliceFloat64.
+type RollingOptions struct {
+	// Window is the number of positions covered by each window.
+	Window int
+	// MinPeriods is the minimum number of non-NaN values a window
+	// must contain to produce a result; windows with fewer values
+	// produce NaN. A value of 0 (or greater than Window) means Window.
+	MinPeriods int
+	// Center will align each result with the center of its window
+	// instead of with the last position of the window.
+	Center bool
+}
+
+// rollingAccumulator maintains a statistic over the values
+// that are currently inside a window. Only the indices of
+// non-NaN values are added and removed, in FIFO order.
+type rollingAccumulator interface {
+	add(i int)
+	remove(i int)
+	value(count int) float64
+}
+
+// RollingSum will return the sum of each window, aligned
+// to the positions of the slice.
+func (s SliceFloat64) RollingSum(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingMoments{s: s, sum: true})
+}
+
+// RollingMean will return the mean of each window, aligned
+// to the positions of the slice.
+func (s SliceFloat64) RollingMean(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingMoments{s: s})
+}
+
+// RollingStd will return the population standard deviation
+// of each window, aligned to the positions of the slice.
+func (s SliceFloat64) RollingStd(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingMoments{s: s, std: true})
+}
+
+// RollingSampleStd will return the sample standard deviation
+// of each window, aligned to the positions of the slice.
+// Windows with a single value have a sample standard deviation of 0.
+func (s SliceFloat64) RollingSampleStd(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingMoments{s: s, std: true, sample: true})
+}
+
+// RollingMin will return the smallest value of each window,
+// aligned to the positions of the slice.
+func (s SliceFloat64) RollingMin(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingExtreme{s: s, less: func(a, b float64) bool {
+		return a <= b
+	}})
+}
+
+// RollingMax will return the largest value of each window,
+// aligned to the positions of the slice.
+func (s SliceFloat64) RollingMax(opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, &rollingExtreme{s: s, less: func(a, b float64) bool {
+		return a >= b
+	}})
+}
+
+// RollingMedian will return the median of each window,
+// aligned to the positions of the slice.
+func (s SliceFloat64) RollingMedian(opts RollingOptions) SliceFloat64 {
+	return s.RollingQuantile(0.5, QuantileLinear, opts)
+}
+
+// RollingQuantile will return the q-th quantile of each window,
+// aligned to the positions of the slice.
+func (s SliceFloat64) RollingQuantile(q float64, method QuantileMethod, opts RollingOptions) SliceFloat64 {
+	return s.rolling(opts, newRollingQuantile(s, q, method))
+}
+
+// rolling will slide a window over the slice, feeding the values
+// entering and leaving the window to the accumulator, and return
+// the accumulated statistic for every position.
+func (s SliceFloat64) rolling(opts RollingOptions, acc rollingAccumulator) SliceFloat64 {
+	results := make(SliceFloat64, len(s))
+
+	window := opts.Window
+	if window < 1 {
+		for i := range results {
+			results[i] = math.NaN()
+		}
+		return results
+	}
+
+	minPeriods := opts.MinPeriods
+	if minPeriods <= 0 || minPeriods > window {
+		minPeriods = window
+	}
+
+	// the result for position i is taken from the
+	// window ending at position i+offset.
+	var offset int
+	if opts.Center {
+		offset = window - 1 - window/2
+	}
+
+	var count int
+	for end := 0; end < len(s)+offset; end++ {
+		if end < len(s) && !math.IsNaN(s[end]) {
+			acc.add(end)
+			count++
+		}
+
+		if out := end - window; out >= 0 && !math.IsNaN(s[out]) {
+			acc.remove(out)
+			count--
+		}
+
+		i := end - offset
+		if i < 0 {
+			continue
+		}
+
+		if count >= minPeriods && count > 0 {
+			results[i] = acc.value(count)
+		} else {
+			results[i] = math.NaN()
+		}
+	}
+
+	return results
+}
+
+// rollingMoments tracks the sum, mean and variance of a window.
+type rollingMoments struct {
+	s      SliceFloat64
+	sum    bool
+	std    bool
+	sample bool
+
+	total float64
+	mean  float64
+	m2    float64
+	n     int
+}
+
+func (r *rollingMoments) add(i int) {
+	x := r.s[i]
+	r.total += x
+
+	r.n++
+	delta := x - r.mean
+	r.mean += delta / float64(r.n)
+	r.m2 += delta * (x - r.mean)
+}
+
+func (r *rollingMoments) remove(i int) {
+	x := r.s[i]
+	r.total -= x
+
+	r.n--
+	if r.n == 0 {
+		r.total, r.mean, r.m2 = 0, 0, 0
+		return
+	}
+
+	delta := x - r.mean
+	r.mean -= delta / float64(r.n)
+	r.m2 -= delta * (x - r.mean)
+	if r.m2 < 0 {
+		r.m2 = 0
+	}
+}
+
+func (r *rollingMoments) value(count int) float64 {
+	switch {
+	case r.sum:
+		return r.total
+	case r.std && r.sample:
+		if count < 2 {
+			return 0
+		}
+		return math.Sqrt(r.m2 / float64(count-1))
+	case r.std:
+		return math.Sqrt(r.m2 / float64(count))
+	default:
+		return r.mean
+	}
+}
+
+// rollingExtreme keeps a monotonic deque of indices so that
+// the front is always the extreme value of the window.
+type rollingExtreme struct {
+	s     SliceFloat64
+	less  func(a, b float64) bool
+	deque []int
+}
+
+func (r *rollingExtreme) add(i int) {
+	for len(r.deque) > 0 && r.less(r.s[i], r.s[r.deque[len(r.deque)-1]]) {
+		r.deque = r.deque[:len(r.deque)-1]
+	}
+	r.deque = append(r.deque, i)
+}
+
+func (r *rollingExtreme) remove(i int) {
+	if len(r.deque) > 0 && r.deque[0] == i {
+		r.deque = r.deque[1:]
+	}
+}
+
+func (r *rollingExtreme) value(int) float64 {
+	return r.s[r.deque[0]]
+}
+
+// rollingQuantile keeps the ranks of the values in the window
+// in a Fenwick tree so that any order statistic of the window
+// can be found in O(log n).
+type rollingQuantile struct {
+	q      float64
+	method QuantileMethod
+
+	rank   []int
+	sorted SliceFloat64
+	tree   []int
+}
+
+func newRollingQuantile(s SliceFloat64, q float64, method QuantileMethod) *rollingQuantile {
+	indices := make([]int, 0, len(s))
+	for i, v := range s {
+		if !math.IsNaN(v) {
+			indices = append(indices, i)
+		}
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return s[indices[a]] < s[indices[b]]
+	})
+
+	r := &rollingQuantile{
+		q:      q,
+		method: method,
+		rank:   make([]int, len(s)),
+		sorted: make(SliceFloat64, len(indices)),
+		tree:   make([]int, len(indices)+1),
+	}
+	for rank, i := range indices {
+		r.rank[i] = rank
+		r.sorted[rank] = s[i]
+	}
+
+	return r
+}
+
+func (r *rollingQuantile) add(i int) {
+	r.update(r.rank[i], 1)
+}
+
+func (r *rollingQuantile) remove(i int) {
+	r.update(r.rank[i], -1)
+}
+
+func (r *rollingQuantile) value(count int) float64 {
+	return quantileAt(count, r.q, r.method, r.kth)
+}
+
+func (r *rollingQuantile) update(rank, delta int) {
+	for i := rank + 1; i < len(r.tree); i += i & -i {
+		r.tree[i] += delta
+	}
+}
+
+// kth will return the k-th (0-based) smallest value in the window.
+func (r *rollingQuantile) kth(k int) float64 {
+	var pos int
+	step := 1
+	for step*2 < len(r.tree) {
+		step *= 2
+	}
+
+	for ; step > 0; step /= 2 {
+		if next := pos + step; next < len(r.tree) && r.tree[next] <= k {
+			pos = next
+			k -= r.tree[next]
+		}
+	}
+
+	return r.sorted[pos]
+}
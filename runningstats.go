@@ -0,0 +1,192 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "math"
+
+// RunningStats accumulates summary statistics over a stream of
+// values without storing them, using Welford's algorithm extended
+// to the third and fourth central moments.
+// The statistics match the SliceFloat64 methods of the same name
+// for the same values. NaN values are counted but otherwise ignored.
+// The zero value is ready to use.
+type RunningStats struct {
+	n        int
+	nanCount int
+	mean     float64
+	m2       float64
+	m3       float64
+	m4       float64
+	min      float64
+	max      float64
+}
+
+// Push will add a value to the accumulator.
+func (r *RunningStats) Push(x float64) {
+	if math.IsNaN(x) {
+		r.nanCount++
+		return
+	}
+
+	if r.n == 0 || x < r.min {
+		r.min = x
+	}
+	if r.n == 0 || x > r.max {
+		r.max = x
+	}
+
+	n1 := float64(r.n)
+	r.n++
+	n := float64(r.n)
+
+	delta := x - r.mean
+	deltaN := delta / n
+	deltaN2 := deltaN * deltaN
+	term1 := delta * deltaN * n1
+
+	r.mean += deltaN
+	r.m4 += term1*deltaN2*(n*n-3*n+3) + 6*deltaN2*r.m2 - 4*deltaN*r.m3
+	r.m3 += term1*deltaN*(n-2) - 3*deltaN*r.m2
+	r.m2 += term1
+}
+
+// PushSlice will add every value of the slice to the accumulator.
+func (r *RunningStats) PushSlice(s SliceFloat64) {
+	for _, v := range s {
+		r.Push(v)
+	}
+}
+
+// Merge will combine the values accumulated by other into
+// this accumulator, as if every value had been pushed to it.
+// This allows accumulators from separate goroutines to be combined.
+func (r *RunningStats) Merge(other RunningStats) {
+	if other.n == 0 {
+		r.nanCount += other.nanCount
+		return
+	}
+	if r.n == 0 {
+		other.nanCount += r.nanCount
+		*r = other
+		return
+	}
+
+	na, nb := float64(r.n), float64(other.n)
+	n := na + nb
+	delta := other.mean - r.mean
+	delta2 := delta * delta
+	delta3 := delta2 * delta
+	delta4 := delta2 * delta2
+
+	mean := (na*r.mean + nb*other.mean) / n
+	m2 := r.m2 + other.m2 + delta2*na*nb/n
+	m3 := r.m3 + other.m3 +
+		delta3*na*nb*(na-nb)/(n*n) +
+		3*delta*(na*other.m2-nb*r.m2)/n
+	m4 := r.m4 + other.m4 +
+		delta4*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*delta2*(na*na*other.m2+nb*nb*r.m2)/(n*n) +
+		4*delta*(na*other.m3-nb*r.m3)/n
+
+	r.n += other.n
+	r.nanCount += other.nanCount
+	r.mean, r.m2, r.m3, r.m4 = mean, m2, m3, m4
+	r.min = math.Min(r.min, other.min)
+	r.max = math.Max(r.max, other.max)
+}
+
+// Count will return the number of non-NaN values pushed.
+func (r *RunningStats) Count() int {
+	return r.n
+}
+
+// NaNCount will return the number of NaN values pushed.
+func (r *RunningStats) NaNCount() int {
+	return r.nanCount
+}
+
+// Mean will return the mean of the values pushed.
+func (r *RunningStats) Mean() float64 {
+	return r.mean
+}
+
+// Sum will return the sum of the values pushed.
+func (r *RunningStats) Sum() float64 {
+	return r.mean * float64(r.n)
+}
+
+// Min will return the smallest value pushed,
+// or 0 if no values have been pushed.
+func (r *RunningStats) Min() float64 {
+	return r.min
+}
+
+// Max will return the largest value pushed,
+// or 0 if no values have been pushed.
+func (r *RunningStats) Max() float64 {
+	return r.max
+}
+
+// Variance will return the population variance
+// of the values pushed.
+func (r *RunningStats) Variance() float64 {
+	if r.n == 0 {
+		return 0
+	}
+
+	return r.m2 / float64(r.n)
+}
+
+// SampleVariance will return the (Bessel corrected) sample
+// variance of the values pushed.
+func (r *RunningStats) SampleVariance() float64 {
+	if r.n < 2 {
+		return 0
+	}
+
+	return r.m2 / float64(r.n-1)
+}
+
+// StdDev will return the population standard deviation
+// of the values pushed.
+func (r *RunningStats) StdDev() float64 {
+	return math.Sqrt(r.Variance())
+}
+
+// SampleStdDev will return the sample standard deviation
+// of the values pushed.
+func (r *RunningStats) SampleStdDev() float64 {
+	return math.Sqrt(r.SampleVariance())
+}
+
+// Skewness will return the population skewness (g1)
+// of the values pushed.
+func (r *RunningStats) Skewness() float64 {
+	if r.n == 0 || r.m2 == 0 {
+		return 0
+	}
+
+	return math.Sqrt(float64(r.n)) * r.m3 / math.Pow(r.m2, 1.5)
+}
+
+// Kurtosis will return the population excess kurtosis (g2)
+// of the values pushed.
+func (r *RunningStats) Kurtosis() float64 {
+	if r.n == 0 || r.m2 == 0 {
+		return 0
+	}
+
+	return float64(r.n)*r.m4/(r.m2*r.m2) - 3
+}
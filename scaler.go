@@ -0,0 +1,202 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import "math"
+
+// Scaler learns the parameters of a transform from one
+// SliceFloat64 so that the same transform can be applied
+// to (and reversed on) any other SliceFloat64.
+// Transform and InverseTransform always return new slices.
+//
+// The scalers in this package can be marshaled to and from JSON
+// so that a scaler fitted on training data can be reused later.
+type Scaler interface {
+	Fit(SliceFloat64)
+	Transform(SliceFloat64) SliceFloat64
+	FitTransform(SliceFloat64) SliceFloat64
+	InverseTransform(SliceFloat64) SliceFloat64
+}
+
+// MinMaxScaler rescales values to the [0,1] range
+// of the data it was fit on.
+type MinMaxScaler struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Fit will record the min and max values of the slice.
+func (m *MinMaxScaler) Fit(s SliceFloat64) {
+	m.Min, m.Max = s.Min(), s.Max()
+}
+
+// Transform will return a copy of the slice rescaled by the
+// fitted min and max. If the fitted min and max are equal
+// all values are scaled to 0.
+func (m *MinMaxScaler) Transform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		if m.Max == m.Min {
+			return 0
+		}
+		return (v - m.Min) / (m.Max - m.Min)
+	})
+}
+
+// FitTransform will fit the scaler to the slice and return
+// the transformed copy.
+func (m *MinMaxScaler) FitTransform(s SliceFloat64) SliceFloat64 {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// InverseTransform will return a copy of the slice mapped
+// from the [0,1] range back to the fitted range.
+func (m *MinMaxScaler) InverseTransform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		return v*(m.Max-m.Min) + m.Min
+	})
+}
+
+// StandardScaler centers values on the mean and scales them
+// to unit (population) standard deviation.
+type StandardScaler struct {
+	Mean float64 `json:"mean"`
+	Std  float64 `json:"std"`
+}
+
+// Fit will record the mean and standard deviation of the slice.
+func (m *StandardScaler) Fit(s SliceFloat64) {
+	m.Mean, m.Std = s.Avg(), s.StdDev()
+}
+
+// Transform will return a copy of the slice standardized by the
+// fitted mean and standard deviation. If the fitted standard
+// deviation is 0 the values are only centered.
+func (m *StandardScaler) Transform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		if m.Std == 0 {
+			return v - m.Mean
+		}
+		return (v - m.Mean) / m.Std
+	})
+}
+
+// FitTransform will fit the scaler to the slice and return
+// the transformed copy.
+func (m *StandardScaler) FitTransform(s SliceFloat64) SliceFloat64 {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// InverseTransform will return a copy of the slice mapped
+// back to the fitted mean and standard deviation.
+func (m *StandardScaler) InverseTransform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		if m.Std == 0 {
+			return v + m.Mean
+		}
+		return v*m.Std + m.Mean
+	})
+}
+
+// RobustScaler centers values on the median and scales them
+// by the interquartile range, which makes it insensitive to outliers.
+type RobustScaler struct {
+	Median float64 `json:"median"`
+	IQR    float64 `json:"iqr"`
+}
+
+// Fit will record the median and interquartile range of the slice.
+func (m *RobustScaler) Fit(s SliceFloat64) {
+	quartiles := s.Quantiles([]float64{0.25, 0.5, 0.75}, QuantileLinear)
+	m.Median = quartiles[1]
+	m.IQR = quartiles[2] - quartiles[0]
+}
+
+// Transform will return a copy of the slice scaled by the
+// fitted median and interquartile range. If the fitted
+// interquartile range is 0 the values are only centered.
+func (m *RobustScaler) Transform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		if m.IQR == 0 {
+			return v - m.Median
+		}
+		return (v - m.Median) / m.IQR
+	})
+}
+
+// FitTransform will fit the scaler to the slice and return
+// the transformed copy.
+func (m *RobustScaler) FitTransform(s SliceFloat64) SliceFloat64 {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// InverseTransform will return a copy of the slice mapped
+// back to the fitted median and interquartile range.
+func (m *RobustScaler) InverseTransform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		if m.IQR == 0 {
+			return v + m.Median
+		}
+		return v*m.IQR + m.Median
+	})
+}
+
+// LogShiftScaler shifts values so that the smallest fitted
+// value is at least 1 and then takes their natural logarithm.
+type LogShiftScaler struct {
+	Shift float64 `json:"shift"`
+}
+
+// Fit will record the shift required by the slice.
+func (m *LogShiftScaler) Fit(s SliceFloat64) {
+	m.Shift = s.logShift()
+}
+
+// Transform will return a copy of the slice shifted by the
+// fitted shift and log scaled. Values that are still not
+// strictly positive after the shift produce NaN or -Inf.
+func (m *LogShiftScaler) Transform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		return math.Log(v + m.Shift)
+	})
+}
+
+// FitTransform will fit the scaler to the slice and return
+// the transformed copy.
+func (m *LogShiftScaler) FitTransform(s SliceFloat64) SliceFloat64 {
+	m.Fit(s)
+	return m.Transform(s)
+}
+
+// InverseTransform will return a copy of the slice exponentiated
+// and shifted back by the fitted shift.
+func (m *LogShiftScaler) InverseTransform(s SliceFloat64) SliceFloat64 {
+	return s.apply(func(v float64) float64 {
+		return math.Exp(v) - m.Shift
+	})
+}
+
+// apply will return a new slice containing the
+// result of fn for every value in the slice.
+func (s SliceFloat64) apply(fn func(float64) float64) SliceFloat64 {
+	applied := make(SliceFloat64, len(s))
+	for i, v := range s {
+		applied[i] = fn(v)
+	}
+
+	return applied
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinMaxScalerIgnoresNaN(t *testing.T) {
+	var scaler MinMaxScaler
+	scaled := scaler.FitTransform(SliceFloat64{math.NaN(), 1, 2, 3})
+
+	if !math.IsNaN(scaled[0]) || scaled[1] != 0 || scaled[2] != 0.5 || scaled[3] != 1 {
+		t.Errorf("FitTransform() = %v, want [NaN 0 0.5 1]", scaled)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Set is a hash set of comparable values.
+// It can be built by simply calling make(Set[T]).
+type Set[T comparable] map[T]struct{}
+
+// SetInt is a Set of integers.
+type SetInt = Set[int]
+
+// SetInt64 is a Set of int64 values.
+type SetInt64 = Set[int64]
+
+// SetString is a Set of strings.
+type SetString = Set[string]
+
+// NewSet will return a Set containing the supplied values.
+func NewSet[T comparable](values ...T) Set[T] {
+	s := make(Set[T], len(values))
+	for _, v := range values {
+		s.Add(v)
+	}
+
+	return s
+}
+
+// ToSet will return a Set containing the values of the slice.
+func (s SliceInt) ToSet() SetInt {
+	return NewSet(s...)
+}
+
+// ToSet will return a Set containing the values of the slice.
+func (s SliceInt64) ToSet() SetInt64 {
+	return NewSet(s...)
+}
+
+// ToSet will return a Set containing the values of the slice.
+func (s SliceString) ToSet() SetString {
+	return NewSet(s...)
+}
+
+// Unique will return the distinct values of the
+// slice in the order they first appear.
+func (s SliceInt) Unique() SliceInt {
+	return unique(s)
+}
+
+// Unique will return the distinct values of the
+// slice in the order they first appear.
+func (s SliceInt64) Unique() SliceInt64 {
+	return unique(s)
+}
+
+// Unique will return the distinct values of the
+// slice in the order they first appear.
+func (s SliceString) Unique() SliceString {
+	return unique(s)
+}
+
+// Add will add the value to the set.
+func (s Set[T]) Add(v T) {
+	s[v] = struct{}{}
+}
+
+// Remove will remove the value from the set.
+func (s Set[T]) Remove(v T) {
+	delete(s, v)
+}
+
+// Has will check if the value is in the set.
+func (s Set[T]) Has(v T) bool {
+	_, ok := s[v]
+	return ok
+}
+
+// Len will return the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s)
+}
+
+// Union will return a new set of the values
+// found in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := make(Set[T], len(s)+len(other))
+	for v := range s {
+		union.Add(v)
+	}
+	for v := range other {
+		union.Add(v)
+	}
+
+	return union
+}
+
+// Intersection will return a new set of the values
+// found in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+
+	intersection := make(Set[T])
+	for v := range small {
+		if large.Has(v) {
+			intersection.Add(v)
+		}
+	}
+
+	return intersection
+}
+
+// Difference will return a new set of the values
+// found in this set but not in the other set.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := make(Set[T])
+	for v := range s {
+		if !other.Has(v) {
+			difference.Add(v)
+		}
+	}
+
+	return difference
+}
+
+// SymmetricDifference will return a new set of the values
+// found in exactly one of the two sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	difference := s.Difference(other)
+	for v := range other {
+		if !s.Has(v) {
+			difference.Add(v)
+		}
+	}
+
+	return difference
+}
+
+// IsSubset will check if every value of this set
+// is also in the other set.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+
+	for v := range s {
+		if !other.Has(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsSuperset will check if every value of the other
+// set is also in this set.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal will check if both sets contain the same values.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// Jaccard will return the Jaccard similarity of the two sets,
+// the size of their intersection divided by the size of their union.
+// Two empty sets have a similarity of 1.
+func (s Set[T]) Jaccard(other Set[T]) float64 {
+	intersection := s.Intersection(other).Len()
+	union := len(s) + len(other) - intersection
+	if union == 0 {
+		return 1
+	}
+
+	return float64(intersection) / float64(union)
+}
+
+// Values will return the values of the set in no particular order.
+func (s Set[T]) Values() []T {
+	return Keys(s)
+}
+
+// SetToSlice will return the values of the set as a slice of
+// type S, such as SliceInt or SliceString, in ascending order if
+// sorted is true.
+func SetToSlice[S ~[]E, E cmp.Ordered](s Set[E], sorted bool) S {
+	values := S(Keys(s))
+	if sorted {
+		slices.Sort(values)
+	}
+
+	return values
+}
+
+// unique will return the distinct values of the
+// slice in the order they first appear.
+func unique[S ~[]E, E comparable](s S) S {
+	seen := make(Set[E], len(s))
+	values := make(S, 0, len(s))
+	for _, v := range s {
+		if seen.Has(v) {
+			continue
+		}
+		seen.Add(v)
+		values = append(values, v)
+	}
+
+	return values
+}
@@ -24,12 +24,18 @@ var (
 	Float64Type = fmt.Sprint(reflect.TypeOf(float64(1)))
 	StringType  = fmt.Sprint(reflect.TypeOf(""))
 	Int64Type   = fmt.Sprint(reflect.TypeOf(int64(1)))
+	IntType     = fmt.Sprint(reflect.TypeOf(int(1)))
 )
 
 // Slice is a generic interface that
 // can be used to represent any slice
 // type.
 // Current primary use is for external packages.
+//
+// Equal must only return true if the argument is a slice of the
+// same concrete type and length with the same values in the same
+// positions; order-insensitive comparisons are provided by the
+// EqualAsSet and EqualAsMultiset methods of each slice type.
 type Slice interface {
 	Equal(interface{}) bool
 	Get(int) interface{}
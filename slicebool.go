@@ -14,28 +14,15 @@
 
 package sam
 
+import "slices"
+
 // SliceBool is a slice/array of boolean values.
 type SliceBool []bool
 
 // Equal will check if SliceBool is equal to provided
 // object.
 func (s SliceBool) Equal(element interface{}) bool {
-	input, ok := element.(SliceBool)
-	if !ok {
-		return false
-	}
-
-	if len(input) != len(s) {
-		return false
-	}
-
-	for i, v := range s {
-		if v != input[i] {
-			return false
-		}
-	}
-
-	return true
+	return equalSlice(s, element)
 }
 
 // Len will return the length
@@ -53,10 +40,7 @@ func (s SliceBool) Get(index int) interface{} {
 // Set is just a function version of what can be done
 // more easily with `[index]`.
 func (s SliceBool) Set(index int, input interface{}) {
-	v, ok := input.(bool)
-	if ok {
-		s[index] = v
-	}
+	setValue(s, index, input)
 }
 
 // Subslice is just to satisfy the slice interface.
@@ -70,6 +54,12 @@ func (s SliceBool) Type() string {
 	return BoolType
 }
 
+// Copy will return a new SliceBool with the same
+// values that does not share memory with this slice.
+func (s SliceBool) Copy() SliceBool {
+	return slices.Clone(s)
+}
+
 // FalseIndices will return the list of all indices
 // in the slice that have a false value.
 func (s SliceBool) FalseIndices() SliceInt {
@@ -96,8 +86,12 @@ func (s SliceBool) FalseCount() (count int) {
 }
 
 // FalsePercentage will return the percentage of values
-// that are false.
+// that are false. An empty slice returns 0.
 func (s SliceBool) FalsePercentage() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
 	return float64(s.FalseCount()) / float64(len(s))
 }
 
@@ -127,7 +121,11 @@ func (s SliceBool) TrueCount() (count int) {
 }
 
 // TruePercentage will return the percentage of values
-// that are true.
+// that are true. An empty slice returns 0.
 func (s SliceBool) TruePercentage() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
 	return float64(s.TrueCount()) / float64(len(s))
 }
@@ -18,37 +18,26 @@ import (
 	"errors"
 	"math"
 	"math/rand"
+	"slices"
 	"sort"
 
 	hMath "github.com/humilityai/math"
 )
 
 var (
-	ErrBounds = errors.New("index out of bounds")
+	ErrEmpty          = errors.New("input is empty")
+	ErrBounds         = errors.New("index out of bounds")
+	ErrLengthMismatch = errors.New("slices have different lengths")
 )
 
 // SliceFloat64 is the primary data structure for holding numerical
 // data.
 type SliceFloat64 []float64
 
-// Equal ...
+// Equal will check if the supplied object argument is a
+// SliceFloat64 with the same values in the same positions.
 func (s SliceFloat64) Equal(element interface{}) bool {
-	input, ok := element.(SliceFloat64)
-	if !ok {
-		return false
-	}
-
-	if len(input) != len(s) {
-		return false
-	}
-
-	for i, v := range s {
-		if v != input[i] {
-			return false
-		}
-	}
-
-	return true
+	return equalSlice(s, element)
 }
 
 // Get is just a function version of what can be done
@@ -60,10 +49,7 @@ func (s SliceFloat64) Get(index int) interface{} {
 // Set is just a function version of what can be done
 // more easily with `[index]`.
 func (s SliceFloat64) Set(index int, input interface{}) {
-	v, ok := input.(float64)
-	if ok {
-		s[index] = v
-	}
+	setValue(s, index, input)
 }
 
 // Subslice is just to satisfy the slice interface
@@ -77,47 +63,72 @@ func (s SliceFloat64) Type() string {
 	return Float64Type
 }
 
+// Copy will return a new SliceFloat64 with the same
+// values that does not share memory with this slice.
+func (s SliceFloat64) Copy() SliceFloat64 {
+	return slices.Clone(s)
+}
+
+// Sorted will return an ascending sorted copy of the slice,
+// with any NaN values at the end.
+func (s SliceFloat64) Sorted() SliceFloat64 {
+	sorted := s.Copy()
+	sorted.SortInPlace()
+	return sorted
+}
+
+// SortInPlace will sort the slice itself in ascending order,
+// with any NaN values at the end.
+func (s SliceFloat64) SortInPlace() {
+	sort.Sort(s)
+}
+
 // MultiplyBy will mutliply all values in the slice
 // by the value provided.
+//
+// Deprecated: use MultiplyByInPlace, or MultipliedBy for a copy.
 func (s SliceFloat64) MultiplyBy(x float64) {
+	s.MultiplyByInPlace(x)
+}
+
+// MultiplyByInPlace will mutliply all values in the slice
+// itself by the value provided.
+func (s SliceFloat64) MultiplyByInPlace(x float64) {
 	for i, value := range s {
 		s[i] = value * x
 	}
 }
 
-// Min ...
-func (s SliceFloat64) Min() float64 {
-	if len(s) == 0 {
-		return 0
-	}
-	min := math.MaxFloat64
-
-	for _, value := range s {
-		if value < min {
-			min = value
-		}
-	}
+// MultipliedBy will return a copy of the slice with all
+// values multiplied by the value provided.
+func (s SliceFloat64) MultipliedBy(x float64) SliceFloat64 {
+	multiplied := s.Copy()
+	multiplied.MultiplyByInPlace(x)
+	return multiplied
+}
 
+// Min will return the smallest value in the slice, ignoring NaN values.
+// An empty slice returns 0 and a slice of only NaN values returns NaN.
+func (s SliceFloat64) Min() float64 {
+	_, min := Min(s)
 	return min
 }
 
-// MinIndex ...
+// MinIndex will return the index and the value of the smallest
+// value in the slice, ignoring NaN values. The index is -1 if
+// the slice is empty or only has NaN values.
 func (s SliceFloat64) MinIndex() (int, float64) {
-	min := math.MaxFloat64
-	var index int
-
-	for i, value := range s {
-		if value < min {
-			min = value
-			index = i
-		}
-	}
-
-	return index, min
+	return Min(s)
 }
 
-// AverageDeviation ...
+// AverageDeviation will return the mean absolute deviation
+// of the values in the slice from their mean. An empty slice
+// returns 0 and any NaN value makes the result NaN.
 func (s SliceFloat64) AverageDeviation() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
 	avg := s.Avg()
 	var totalDev float64
 	for _, v := range s {
@@ -140,61 +151,25 @@ func (s SliceFloat64) IsNum() SliceFloat64 {
 	return newS
 }
 
-// Median ...
-func (s SliceFloat64) Median() (index int, value float64) {
-	if len(s) == 0 {
-		return 0, 0
-	}
-	sort.Sort(s)
-
-	index = len(s) / 2
-	value = s[index]
-
-	return
-}
-
 // Sum will return the sum of all the values
-// in the SliceFloat64.
+// in the SliceFloat64. Any NaN value makes the sum NaN.
 func (s SliceFloat64) Sum() (sum float64) {
-	for _, v := range s {
-		sum += v
-	}
-
-	return
+	return Sum(s)
 }
 
-// Max will return the largest value in the slice.
+// Max will return the largest value in the slice, ignoring NaN values.
+// An empty slice returns 0 and a slice of only NaN values returns NaN.
 func (s SliceFloat64) Max() float64 {
-	if len(s) == 0 {
-		return 0
-	}
-	max := math.SmallestNonzeroFloat64
-
-	for _, value := range s {
-		if value > max {
-			max = value
-		}
-	}
-
+	_, max := Max(s)
 	return max
 }
 
-// MaxIndex will return the index of the maximum float64 value
+// MaxIndex will return the index of the maximum float64 value,
+// ignoring NaN values. The index is -1 if the slice is empty
+// or only has NaN values.
 func (s SliceFloat64) MaxIndex() (index int) {
-	if len(s) == 0 {
-		return 0
-	}
-
-	max := math.SmallestNonzeroFloat64
-
-	for i, value := range s {
-		if value > max {
-			max = value
-			index = i
-		}
-	}
-
-	return index
+	index, _ = Max(s)
+	return
 }
 
 // BoundedSum will return the final index
@@ -213,60 +188,33 @@ func (s SliceFloat64) BoundedSum(bound float64) int {
 }
 
 // MaxN will return a []float64 of the largest-N
-// values in the slice.
+// values in the slice, from the largest to the smallest.
 func (s SliceFloat64) MaxN(n int) SliceFloat64 {
-	if n <= 0 {
-		return make(SliceFloat64, 0)
-	}
-
-	var maxN SliceFloat64
-	for _, value := range s {
-		if len(maxN) < n {
-			maxN = append(maxN, value)
-		} else {
-			minIndex, minValue := maxN.MinIndex()
-			if value > minValue {
-				maxN[minIndex] = value
-			}
-		}
-	}
-
+	maxN, _ := s.TopK(n)
 	return maxN
 }
 
 // MaxNWithIndex will return a map[int]float64 of the largest-N values
 // and the index that they reside at: map[index]value
 func (s SliceFloat64) MaxNWithIndex(n int) MapIntFloat64 {
-	if n <= 0 {
-		return make(MapIntFloat64)
-	}
-
-	var maxN MapIntFloat64
-	for i, v := range s {
-		if len(maxN) < n {
-			maxN[i] = v
-		} else {
-			minIndex := maxN.Min()
-			if v > maxN[minIndex] {
-				maxN[minIndex] = v
-			}
-		}
+	values, indices := s.TopK(n)
+	maxN := make(MapIntFloat64, len(indices))
+	for i, index := range indices {
+		maxN[index] = values[i]
 	}
 
 	return maxN
 }
 
-// Avg will return the mean of the values
-// in the slice.
+// Avg will return the mean of the values in the slice.
+// An empty slice has a mean of 0 and any NaN value
+// makes the mean NaN.
 func (s SliceFloat64) Avg() float64 {
-	var total float64
-	for _, value := range s {
-		total += value
+	if len(s) == 0 {
+		return 0
 	}
 
-	avg := total / float64(len(s))
-
-	return avg
+	return s.Sum() / float64(len(s))
 }
 
 // MajorityZero will return true iff the number of
@@ -345,26 +293,22 @@ func (s SliceFloat64) NonZeroBounds() (min float64, max float64) {
 	return
 }
 
-// Range ...
+// Range will return the difference between the largest and the
+// smallest value in the slice, ignoring NaN values. An empty slice
+// returns 0 and a slice of only NaN values returns NaN.
 func (s SliceFloat64) Range() float64 {
-	max := math.SmallestNonzeroFloat64
-	min := math.MaxFloat64
-	for _, value := range s {
-		if value > max {
-			max = value
-		}
-		if value < min {
-			min = value
-		}
-	}
-
-	return math.Abs(max - min)
+	return s.Max() - s.Min()
 }
 
-// Mode ...
+// Mode will return the most frequent value in the slice,
+// ignoring NaN values. An empty slice, or a slice of only
+// NaN values, returns 0.
 func (s SliceFloat64) Mode() float64 {
 	counts := make(MapFloat64Int)
 	for _, value := range s {
+		if math.IsNaN(value) {
+			continue
+		}
 		v, ok := counts[value]
 		if !ok {
 			counts[value] = 1
@@ -393,13 +337,7 @@ func (s SliceFloat64) Counts() MapFloat64Int {
 // the supplied argument. Use this method for better best-case performance and
 // a guaranteed smaller memory footprint.
 func (s SliceFloat64) Contains(f float64) bool {
-	for _, value := range s {
-		if value == f {
-			return true
-		}
-	}
-
-	return false
+	return Contains(s, f)
 }
 
 // GreaterThan will return the indices, total count, and percentage of slice of the float64 numbers
@@ -480,38 +418,81 @@ func (s SliceFloat64) StrictlyNegative() bool {
 	return true
 }
 
-// RescaleValues will rescale the values to either [0,1]
-// or [-1,1] range. It returns the min and max values found
-// in the scalar slice.
+// RescaleValues will return the min and max values found
+// in the slice.
+//
+// Deprecated: RescaleValues never modified the slice. Use Rescale
+// for a rescaled copy or RescaleInPlace to rescale the slice itself.
 func (s SliceFloat64) RescaleValues() (min, max float64) {
+	return s.Min(), s.Max()
+}
+
+// Rescale will return a copy of the slice with the values
+// rescaled to the [0,1] range, along with the min and max
+// values found in the slice.
+func (s SliceFloat64) Rescale() (scaled SliceFloat64, min, max float64) {
+	scaled = s.Copy()
+	min, max = scaled.RescaleInPlace()
+	return
+}
+
+// RescaleInPlace will rescale the values of the slice itself
+// to the [0,1] range and return the min and max values found.
+// If all values are equal they are rescaled to 0.
+func (s SliceFloat64) RescaleInPlace() (min, max float64) {
 	min = s.Min()
 	max = s.Max()
 
-	scaledValues := make(SliceFloat64, len(s), len(s))
 	for index, value := range s {
-		scaledValue := (value - min) / (max - min)
-		scaledValues[index] = scaledValue
+		if max == min {
+			s[index] = 0
+			continue
+		}
+		s[index] = (value - min) / (max - min)
 	}
 
-	s = scaledValues
 	return
 }
 
-// ShiftLogScaleValues ...
+// ShiftLogScaleValues will return the shift that would make
+// all values in the slice strictly positive before taking
+// their logarithm.
+//
+// Deprecated: ShiftLogScaleValues never modified the slice. Use
+// ShiftLogScale for a scaled copy or ShiftLogScaleInPlace to scale
+// the slice itself.
 func (s SliceFloat64) ShiftLogScaleValues() (shift float64) {
-	minValue := s.Min()
-	if minValue <= 0 {
-		shift = (0 - minValue) + 1
-	}
+	return s.logShift()
+}
+
+// ShiftLogScale will return a copy of the slice where every value
+// has been shifted to be strictly positive and then log scaled,
+// along with the shift that was applied.
+func (s SliceFloat64) ShiftLogScale() (scaled SliceFloat64, shift float64) {
+	scaled = s.Copy()
+	shift = scaled.ShiftLogScaleInPlace()
+	return
+}
 
-	newData := make(SliceFloat64, len(s), len(s))
+// ShiftLogScaleInPlace will shift every value of the slice itself
+// to be strictly positive and then log scale it. It returns the
+// shift that was applied.
+func (s SliceFloat64) ShiftLogScaleInPlace() (shift float64) {
+	shift = s.logShift()
 	for index, value := range s {
-		shiftedValue := value + shift
-		scaledValue := math.Log(shiftedValue)
-		newData[index] = scaledValue
+		s[index] = math.Log(value + shift)
 	}
 
-	s = newData
+	return
+}
+
+// logShift will return the amount that must be added to
+// every value so that the smallest value is at least 1.
+func (s SliceFloat64) logShift() (shift float64) {
+	minValue := s.Min()
+	if minValue <= 0 {
+		shift = (0 - minValue) + 1
+	}
 
 	return
 }
@@ -740,33 +721,14 @@ func (s SliceFloat64) ExtremaDistances() (distances SliceFloat64) {
 // EqualToSlice will check if two SliceFloat64s are the same length
 // and have the same values in the same order.
 func (s SliceFloat64) EqualToSlice(s2 SliceFloat64) bool {
-	if len(s) != len(s2) {
-		return false
-	}
-
-	for i, v := range s {
-		if s2[i] != v {
-			return false
-		}
-	}
-
-	return true
+	return s.EqualOrdered(s2)
 }
 
 // SimilarTo will check if two SliceFloat64s are the same length
-// and contain the same elements (but not necessarily in the same order).
+// and contain the same elements the same number of times (but not
+// necessarily in the same order).
 func (s SliceFloat64) SimilarTo(s2 SliceFloat64) bool {
-	if len(s) != len(s2) {
-		return false
-	}
-
-	for _, v := range s {
-		if !s2.Contains(v) {
-			return false
-		}
-	}
-
-	return true
+	return s.EqualAsMultiset(s2)
 }
 
 // SamplePercentage will return a random sampling of the slice based on the percentage value.
@@ -856,7 +818,8 @@ func (s SliceFloat64) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
-// Less ...
+// Less will order NaN values after every other value,
+// so that sorting the slice leaves any NaN values at the end.
 func (s SliceFloat64) Less(i, j int) bool {
-	return s[i] < s[j]
+	return s[i] < s[j] || (math.IsNaN(s[j]) && !math.IsNaN(s[i]))
 }
@@ -14,88 +14,84 @@
 
 package sam
 
-import "math"
+import "slices"
 
 // SliceInt is a slice/array of integers
 type SliceInt []int
 
+// Equal will only return true if the object is a SliceInt
+// of the same length and has the same values in the same positions.
+func (s SliceInt) Equal(input interface{}) bool {
+	return equalSlice(s, input)
+}
+
+// Get will return the int value at the specified index
+func (s SliceInt) Get(idx int) interface{} {
+	return s[idx]
+}
+
+// Len will return the length of the SliceInt
+func (s SliceInt) Len() int {
+	return len(s)
+}
+
+// Set will set the provided value at the provided index
+func (s SliceInt) Set(idx int, value interface{}) {
+	setValue(s, idx, value)
+}
+
+// Type will return an IntType value for SliceInt objects
+func (s SliceInt) Type() string {
+	return IntType
+}
+
+// Subslice will return a new SliceInt that is a subslice of
+// this SliceInt
+func (s SliceInt) Subslice(start, end int) Slice {
+	return s[start:end]
+}
+
+// Copy will return a new SliceInt with the same
+// values that does not share memory with this slice.
+func (s SliceInt) Copy() SliceInt {
+	return slices.Clone(s)
+}
+
 // Product will return the total product of
 // all values in the slice.
 func (s SliceInt) Product() int {
-	var product int
-	for i, v := range s {
-		if i == 0 {
-			product = v
-			continue
-		}
-		product *= v
-	}
-
-	return product
+	return Product(s)
 }
 
 // Sum will return the total sum of
 // all values in the slice.
 func (s SliceInt) Sum() int {
-	var sum int
-
-	for i, v := range s {
-		if i == 0 {
-			sum = v
-			continue
-		}
-		sum += v
-	}
-
-	return sum
+	return Sum(s)
 }
 
 // Min will return the index and the value of
-// the smallest value in the slice.
+// the smallest value in the slice. An empty
+// slice returns an index of -1.
 func (s SliceInt) Min() (index, value int) {
-	value = math.MaxInt64
-	for i, v := range s {
-		if v < value {
-			index = i
-			value = v
-		}
-	}
-
-	return
+	return Min(s)
 }
 
 // Max will return the index of the largest
-// value in the slice.
+// value in the slice. An empty slice returns
+// an index of -1.
 func (s SliceInt) Max() (index int) {
-	max := math.MinInt64
-	for i, v := range s {
-		if v > max {
-			v = max
-			index = i
-		}
-	}
-
+	index, _ = Max(s)
 	return
 }
 
 // Contains checks if an integer value
 // already exists in the slice.
 func (s SliceInt) Contains(i int) bool {
-	for _, v := range s {
-		if v == i {
-			return true
-		}
-	}
-
-	return false
+	return Contains(s, i)
 }
 
 // IncrementPosition will add 1 to the integer value
 // found at the supplied index argument.
 func (s SliceInt) IncrementPosition(index int) {
-	if index > len(s)-1 || index < 0 {
-		return
-	}
-
-	s[index]++
+	IncrementPosition(s, index)
 }
@@ -14,7 +14,7 @@
 
 package sam
 
-import "math"
+import "slices"
 
 // SliceInt64 is a slice/array of integers
 type SliceInt64 []int64
@@ -22,22 +22,7 @@ type SliceInt64 []int64
 // Equal will only return true if the object is a SliceInt64
 // of the same length and has smae values in the same positions.
 func (s SliceInt64) Equal(input interface{}) bool {
-	sl, ok := input.(SliceInt64)
-	if !ok {
-		return false
-	}
-
-	if len(sl) != len(s) {
-		return false
-	}
-
-	for i, v := range s {
-		if sl[i] != v {
-			return false
-		}
-	}
-
-	return true
+	return equalSlice(s, input)
 }
 
 // Get will return the int64 value at the specified index
@@ -52,10 +37,7 @@ func (s SliceInt64) Len() int {
 
 // Set will set the provided value at the provided index
 func (s SliceInt64) Set(idx int, value interface{}) {
-	i, ok := value.(int64)
-	if ok {
-		s[idx] = i
-	}
+	setValue(s, idx, value)
 }
 
 // Type will return an Int64Type value for SliceInt64 objects
@@ -69,83 +51,47 @@ func (s SliceInt64) Subslice(start, end int) Slice {
 	return s[start:end]
 }
 
+// Copy will return a new SliceInt64 with the same
+// values that does not share memory with this slice.
+func (s SliceInt64) Copy() SliceInt64 {
+	return slices.Clone(s)
+}
+
 // Product will return the total product of
 // all values in the slice.
 func (s SliceInt64) Product() int64 {
-	var product int64
-	for i, v := range s {
-		if i == 0 {
-			product = v
-			continue
-		}
-		product *= v
-	}
-
-	return product
+	return Product(s)
 }
 
 // Sum will return the total sum of
 // all values in the slice.
 func (s SliceInt64) Sum() int64 {
-	var sum int64
-
-	for i, v := range s {
-		if i == 0 {
-			sum = v
-			continue
-		}
-		sum += v
-	}
-
-	return sum
+	return Sum(s)
 }
 
 // Min will return the index and the value of
-// the smallest value in the slice.
+// the smallest value in the slice. An empty
+// slice returns an index of -1.
 func (s SliceInt64) Min() (index int, value int64) {
-	value = math.MaxInt64
-	for i, v := range s {
-		if v < value {
-			index = i
-			value = v
-		}
-	}
-
-	return
+	return Min(s)
 }
 
 // Max will return the index of the largest
-// value in the slice.
+// value in the slice. An empty slice returns
+// an index of -1.
 func (s SliceInt64) Max() (index int) {
-	max := int64(math.MinInt64)
-	for i, v := range s {
-		if v > max {
-			v = max
-			index = i
-		}
-	}
-
+	index, _ = Max(s)
 	return
 }
 
 // Contains checks if an integer value
 // already exists in the slice.
 func (s SliceInt64) Contains(i int64) bool {
-	for _, v := range s {
-		if v == i {
-			return true
-		}
-	}
-
-	return false
+	return Contains(s, i)
 }
 
 // IncrementPosition will add 1 to the integer value
 // found at the supplied index argument.
 func (s SliceInt64) IncrementPosition(index int) {
-	if index > len(s)-1 || index < 0 {
-		return
-	}
-
-	s[index]++
+	IncrementPosition(s, index)
 }
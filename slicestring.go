@@ -15,34 +15,19 @@
 package sam
 
 import (
+	"slices"
 	"sort"
 	"strings"
-	"unicode"
 )
 
 // SliceString is a slice/array of strings.
 type SliceString []string
 
-// Equal will check if the string slice is equal to
-// the supplied object argument and return a boolean.
+// Equal will check if the supplied object argument is a
+// SliceString with the same strings in the same positions.
 // It can check for equality against any (arbitrary) argument.
 func (s SliceString) Equal(input interface{}) bool {
-	s2, ok := input.(SliceString)
-	if !ok {
-		return false
-	}
-
-	if len(s) != len(s2) {
-		return false
-	}
-
-	for _, value := range s {
-		if !s2.Contains(value) {
-			return false
-		}
-	}
-
-	return true
+	return equalSlice(s, input)
 }
 
 func (s SliceString) Type() string {
@@ -54,11 +39,7 @@ func (s SliceString) Get(idx int) interface{} {
 }
 
 func (s SliceString) Set(idx int, value interface{}) {
-	v, ok := value.(string)
-	if !ok {
-		return
-	}
-	s[idx] = v
+	setValue(s, idx, value)
 }
 
 func (s SliceString) Subslice(start, end int) Slice {
@@ -68,34 +49,61 @@ func (s SliceString) Subslice(start, end int) Slice {
 // Contains will check if the slice contains the supplied
 // string argument.
 func (s SliceString) Contains(input string) bool {
-	for _, v := range s {
-		if v == input {
-			return true
-		}
-	}
+	return Contains(s, input)
+}
+
+// Copy will return a new SliceString with the same
+// values that does not share memory with this slice.
+func (s SliceString) Copy() SliceString {
+	return slices.Clone(s)
+}
 
-	return false
+// Sorted will return a lexicographically sorted copy of the slice.
+// Use SortedBy for a different Collation.
+func (s SliceString) Sorted() SliceString {
+	sorted := s.Copy()
+	sorted.SortInPlace()
+	return sorted
+}
+
+// SortInPlace will lexicographically sort the slice itself.
+func (s SliceString) SortInPlace() {
+	sort.Sort(s)
 }
 
 // ToLower will lowercase all the strings in the slice.
+//
+// Deprecated: use ToLowerInPlace, or Lowered for a copy.
 func (s SliceString) ToLower() {
+	s.ToLowerInPlace()
+}
+
+// ToLowerInPlace will lowercase all the strings in the slice itself.
+func (s SliceString) ToLowerInPlace() {
 	for i, v := range s {
 		s[i] = strings.ToLower(v)
 	}
 }
 
+// Lowered will return a copy of the slice with
+// all of the strings lowercased.
+func (s SliceString) Lowered() SliceString {
+	lowered := s.Copy()
+	lowered.ToLowerInPlace()
+	return lowered
+}
+
 // String will convert the string slice into a single string,
 // with each string being delimited by the supplied delimiter.
 func (s SliceString) String(delimeter string) string {
 	return strings.Join(s, delimeter)
 }
 
-// SortedString will lexicographically sort the strings
+// SortedString will lexicographically sort a copy of the strings
 // in the slice and return them as a single string delimited
-// by the supplied delimieter argument.
+// by the supplied delimieter argument. The slice itself is not reordered.
 func (s SliceString) SortedString(delimeter string) string {
-	sort.Sort(s)
-	return strings.Join(s, delimeter)
+	return strings.Join(s.Sorted(), delimeter)
 }
 
 // Len ...
@@ -108,33 +116,7 @@ func (s SliceString) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
 
-// Less provides lexicographic sorting.
-// Original code: https://stackoverflow.com/a/35087122
+// Less provides lexicographic sorting in the order of CollateDefault.
 func (s SliceString) Less(i, j int) bool {
-	iRunes := []rune(s[i])
-	jRunes := []rune(s[j])
-
-	max := len(iRunes)
-	if max > len(jRunes) {
-		max = len(jRunes)
-	}
-
-	for idx := 0; idx < max; idx++ {
-		ir := iRunes[idx]
-		jr := jRunes[idx]
-
-		lir := unicode.ToLower(ir)
-		ljr := unicode.ToLower(jr)
-
-		if lir != ljr {
-			return lir < ljr
-		}
-
-		// the lowercase runes are the same, so compare the original
-		if ir != jr {
-			return ir < jr
-		}
-	}
-
-	return false
+	return CollateDefault(s[i], s[j]) < 0
 }
@@ -0,0 +1,183 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"math"
+	"sort"
+)
+
+// Description is a summary of the distribution
+// of the values in a SliceFloat64.
+// All fields other than NaNCount are computed
+// over the non-NaN values only.
+type Description struct {
+	Count    int     `json:"count"`
+	NaNCount int     `json:"nan_count"`
+	Mean     float64 `json:"mean"`
+	Std      float64 `json:"std"`
+	Min      float64 `json:"min"`
+	Q1       float64 `json:"q1"`
+	Median   float64 `json:"median"`
+	Q3       float64 `json:"q3"`
+	Max      float64 `json:"max"`
+}
+
+// Variance will return the population variance
+// of the values in the slice.
+// An empty slice has a variance of 0 and any NaN
+// value makes the variance NaN.
+func (s SliceFloat64) Variance() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	_, m2, _, _ := s.moments()
+	return m2
+}
+
+// SampleVariance will return the (Bessel corrected)
+// sample variance of the values in the slice.
+// A slice with less than two values has a sample variance of 0
+// and any NaN value makes the variance NaN.
+func (s SliceFloat64) SampleVariance() float64 {
+	if len(s) < 2 {
+		return 0
+	}
+
+	_, m2, _, _ := s.moments()
+	return m2 * float64(len(s)) / float64(len(s)-1)
+}
+
+// StdDev will return the population standard deviation
+// of the values in the slice. Any NaN value makes it NaN.
+func (s SliceFloat64) StdDev() float64 {
+	return math.Sqrt(s.Variance())
+}
+
+// SampleStdDev will return the sample standard deviation
+// of the values in the slice. Any NaN value makes it NaN.
+func (s SliceFloat64) SampleStdDev() float64 {
+	return math.Sqrt(s.SampleVariance())
+}
+
+// Skewness will return the population skewness (g1)
+// of the values in the slice.
+// It returns 0 for an empty slice or a slice with no spread,
+// and any NaN value makes the skewness NaN.
+func (s SliceFloat64) Skewness() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	_, m2, m3, _ := s.moments()
+	if m2 == 0 {
+		return 0
+	}
+
+	return m3 / math.Pow(m2, 1.5)
+}
+
+// Kurtosis will return the population excess kurtosis (g2)
+// of the values in the slice, so that a normal distribution
+// has a kurtosis of 0.
+// It returns 0 for an empty slice or a slice with no spread,
+// and any NaN value makes the kurtosis NaN.
+func (s SliceFloat64) Kurtosis() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	_, m2, _, m4 := s.moments()
+	if m2 == 0 {
+		return 0
+	}
+
+	return m4/(m2*m2) - 3
+}
+
+// CoefficientOfVariation will return the ratio of the population
+// standard deviation to the mean.
+// It returns 0 for an empty slice, and NaN when the mean
+// is 0 or any value is NaN.
+func (s SliceFloat64) CoefficientOfVariation() float64 {
+	if len(s) == 0 {
+		return 0
+	}
+
+	mean, m2, _, _ := s.moments()
+	if mean == 0 {
+		return math.NaN()
+	}
+
+	return math.Sqrt(m2) / mean
+}
+
+// Describe will return a Description of the values in the slice.
+// The count, mean, standard deviation (sample), min and max are
+// computed in a single pass; the quartiles are computed on a sorted
+// copy so the slice itself is not reordered.
+// An empty (or all NaN) slice returns a zeroed Description.
+func (s SliceFloat64) Describe() Description {
+	var stats RunningStats
+	values := make(SliceFloat64, 0, len(s))
+	for _, v := range s {
+		stats.Push(v)
+		if !math.IsNaN(v) {
+			values = append(values, v)
+		}
+	}
+
+	d := Description{
+		Count:    stats.Count(),
+		NaNCount: stats.NaNCount(),
+	}
+	if d.Count == 0 {
+		return d
+	}
+
+	d.Mean = stats.Mean()
+	d.Std = stats.SampleStdDev()
+	d.Min = stats.Min()
+	d.Max = stats.Max()
+
+	sort.Float64s(values)
+	d.Q1 = values.sortedQuantile(0.25, QuantileLinear)
+	d.Median = values.sortedQuantile(0.5, QuantileLinear)
+	d.Q3 = values.sortedQuantile(0.75, QuantileLinear)
+
+	return d
+}
+
+// moments will return the mean and the second, third
+// and fourth central moments of the values in the slice.
+// The slice must not be empty.
+func (s SliceFloat64) moments() (mean, m2, m3, m4 float64) {
+	mean = s.Avg()
+	for _, v := range s {
+		d := v - mean
+		d2 := d * d
+		m2 += d2
+		m3 += d2 * d
+		m4 += d2 * d2
+	}
+
+	n := float64(len(s))
+	m2 /= n
+	m3 /= n
+	m4 /= n
+
+	return
+}
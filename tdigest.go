@@ -0,0 +1,347 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"sort"
+)
+
+var (
+	ErrTDigestEncoding = errors.New("invalid t-digest encoding")
+)
+
+// tdigestVersion is the first byte of the binary encoding.
+const tdigestVersion = 1
+
+// DefaultTDigestCompression is the compression of the zero TDigest,
+// and of a TDigest created with a compression that is not positive.
+const DefaultTDigestCompression = 100
+
+// TDigest is a mergeable sketch of a distribution that can
+// estimate quantiles and the CDF without storing every value.
+//
+// It is the merging t-digest of Dunning & Ertl using the arcsine
+// scale function, so that at most about compression centroids
+// are kept and accuracy is highest in the tails: the rank error
+// of a quantile estimate is roughly proportional to
+// sqrt(q(1-q))/compression. With the default compression of 100,
+// for single digests and merged digests alike, the median is within
+// 0.25% rank of the exact value and p1 and p99 are within 0.15%.
+// The extreme values are tracked exactly.
+//
+// The zero TDigest is empty and ready to use with a compression
+// of DefaultTDigestCompression. A TDigest is not safe for concurrent
+// use; give every goroutine its own digest and Merge them.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	count       float64
+	min         float64
+	max         float64
+}
+
+// centroid is a cluster of values summarized
+// by their mean and total weight.
+type centroid struct {
+	mean   float64
+	weight float64
+}
+
+// NewTDigest will return an empty TDigest with the supplied
+// compression. Larger compressions are more accurate and use
+// more memory.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression > 0) || math.IsInf(compression, 1) {
+		compression = DefaultTDigestCompression
+	}
+
+	return &TDigest{
+		compression: compression,
+	}
+}
+
+// Add will add a value to the digest. NaN values are ignored.
+func (t *TDigest) Add(x float64) {
+	t.add(centroid{mean: x, weight: 1})
+}
+
+// AddSlice will add every value of the slice to the digest.
+func (t *TDigest) AddSlice(s SliceFloat64) {
+	for _, v := range s {
+		t.Add(v)
+	}
+}
+
+// Merge will add every value summarized by another digest into this digest.
+func (t *TDigest) Merge(other *TDigest) {
+	if other.count == 0 {
+		return
+	}
+
+	// the centroids only hold means, so carry
+	// over the exact extremes separately.
+	min, max := other.min, other.max
+	for _, c := range other.centroids {
+		t.add(c)
+	}
+	for _, c := range other.buffer {
+		t.add(c)
+	}
+
+	t.min = math.Min(t.min, min)
+	t.max = math.Max(t.max, max)
+}
+
+// Count will return the number of values added to the digest.
+func (t *TDigest) Count() int {
+	return int(t.count)
+}
+
+// Min will return the smallest value added, or NaN if the digest is empty.
+func (t *TDigest) Min() float64 {
+	if t.count == 0 {
+		return math.NaN()
+	}
+
+	return t.min
+}
+
+// Max will return the largest value added, or NaN if the digest is empty.
+func (t *TDigest) Max() float64 {
+	if t.count == 0 {
+		return math.NaN()
+	}
+
+	return t.max
+}
+
+// Quantile will estimate the q-th quantile, where q is in [0,1],
+// of the values added. An empty digest returns NaN.
+func (t *TDigest) Quantile(q float64) float64 {
+	t.compress()
+	if t.count == 0 {
+		return math.NaN()
+	}
+	if q <= 0 {
+		return t.min
+	}
+	if q >= 1 {
+		return t.max
+	}
+
+	index := q * t.count
+	first := t.centroids[0]
+	if index < first.weight/2 {
+		return t.min + index/(first.weight/2)*(first.mean-t.min)
+	}
+
+	var cumulative float64
+	for i := 0; i < len(t.centroids)-1; i++ {
+		left, right := t.centroids[i], t.centroids[i+1]
+		leftCenter := cumulative + left.weight/2
+		rightCenter := cumulative + left.weight + right.weight/2
+		if index < rightCenter {
+			fraction := (index - leftCenter) / (rightCenter - leftCenter)
+			return left.mean + fraction*(right.mean-left.mean)
+		}
+		cumulative += left.weight
+	}
+
+	last := t.centroids[len(t.centroids)-1]
+	lastCenter := t.count - last.weight/2
+	fraction := (index - lastCenter) / (last.weight / 2)
+	return last.mean + fraction*(t.max-last.mean)
+}
+
+// CDF will estimate the fraction of values added that
+// are less than or equal to x. An empty digest returns NaN.
+func (t *TDigest) CDF(x float64) float64 {
+	t.compress()
+	if t.count == 0 {
+		return math.NaN()
+	}
+	if x < t.min {
+		return 0
+	}
+	if x >= t.max {
+		return 1
+	}
+
+	first := t.centroids[0]
+	if x < first.mean {
+		if first.mean == t.min {
+			return 0
+		}
+		return (x - t.min) / (first.mean - t.min) * (first.weight / 2) / t.count
+	}
+
+	var cumulative float64
+	for i := 0; i < len(t.centroids)-1; i++ {
+		left, right := t.centroids[i], t.centroids[i+1]
+		if x < right.mean {
+			leftCenter := cumulative + left.weight/2
+			rightCenter := cumulative + left.weight + right.weight/2
+			fraction := (x - left.mean) / (right.mean - left.mean)
+			return (leftCenter + fraction*(rightCenter-leftCenter)) / t.count
+		}
+		cumulative += left.weight
+	}
+
+	last := t.centroids[len(t.centroids)-1]
+	lastCenter := t.count - last.weight/2
+	fraction := (x - last.mean) / (t.max - last.mean)
+	return (lastCenter + fraction*(last.weight/2)) / t.count
+}
+
+// MarshalBinary will encode the digest into a stable
+// big-endian binary format.
+func (t *TDigest) MarshalBinary() ([]byte, error) {
+	t.init()
+	t.compress()
+
+	data := make([]byte, 0, 1+8*4+4+16*len(t.centroids))
+	data = append(data, tdigestVersion)
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(t.compression))
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(t.count))
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(t.min))
+	data = binary.BigEndian.AppendUint64(data, math.Float64bits(t.max))
+	data = binary.BigEndian.AppendUint32(data, uint32(len(t.centroids)))
+	for _, c := range t.centroids {
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(c.mean))
+		data = binary.BigEndian.AppendUint64(data, math.Float64bits(c.weight))
+	}
+
+	return data, nil
+}
+
+// UnmarshalBinary will decode a digest encoded by MarshalBinary,
+// replacing the contents of this digest.
+func (t *TDigest) UnmarshalBinary(data []byte) error {
+	const header = 1 + 8*4 + 4
+	if len(data) < header || data[0] != tdigestVersion {
+		return ErrTDigestEncoding
+	}
+
+	float := func(offset int) float64 {
+		return math.Float64frombits(binary.BigEndian.Uint64(data[offset:]))
+	}
+
+	n := int(binary.BigEndian.Uint32(data[33:]))
+	if len(data) != header+16*n {
+		return ErrTDigestEncoding
+	}
+
+	decoded := TDigest{
+		compression: float(1),
+		count:       float(9),
+		min:         float(17),
+		max:         float(25),
+		centroids:   make([]centroid, n),
+	}
+	if !(decoded.compression > 0) || math.IsInf(decoded.compression, 1) ||
+		!(decoded.count >= 0) || math.IsInf(decoded.count, 1) {
+		return ErrTDigestEncoding
+	}
+	for i := range decoded.centroids {
+		offset := header + 16*i
+		decoded.centroids[i] = centroid{mean: float(offset), weight: float(offset + 8)}
+	}
+
+	*t = decoded
+	return nil
+}
+
+// add will buffer a centroid, compressing the
+// digest once the buffer is full.
+func (t *TDigest) add(c centroid) {
+	if math.IsNaN(c.mean) || c.weight <= 0 {
+		return
+	}
+	t.init()
+
+	if t.count == 0 || c.mean < t.min {
+		t.min = c.mean
+	}
+	if t.count == 0 || c.mean > t.max {
+		t.max = c.mean
+	}
+	t.count += c.weight
+
+	t.buffer = append(t.buffer, c)
+	if len(t.buffer) >= int(5*t.compression) {
+		t.compress()
+	}
+}
+
+// init will give the zero TDigest the default compression.
+func (t *TDigest) init() {
+	if t.compression == 0 {
+		t.compression = DefaultTDigestCompression
+	}
+}
+
+// compress will merge the buffered centroids into the digest,
+// combining neighbouring centroids while the combined centroid
+// spans no more than one unit of the scale function.
+func (t *TDigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := make([]centroid, 0, len(t.centroids)+len(t.buffer))
+	all = append(all, t.centroids...)
+	all = append(all, t.buffer...)
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].mean < all[j].mean
+	})
+
+	merged := make([]centroid, 0, len(t.centroids)+1)
+	merged = append(merged, all[0])
+
+	var soFar float64
+	limit := t.qLimit(0)
+	for _, c := range all[1:] {
+		current := &merged[len(merged)-1]
+		if (soFar+current.weight+c.weight)/t.count <= limit {
+			current.weight += c.weight
+			current.mean += (c.mean - current.mean) * c.weight / current.weight
+			continue
+		}
+
+		soFar += current.weight
+		limit = t.qLimit(soFar / t.count)
+		merged = append(merged, c)
+	}
+
+	t.centroids = merged
+	t.buffer = t.buffer[:0]
+}
+
+// qLimit will return the largest quantile that a centroid starting
+// at quantile q may extend to, using k(q) = c/(2π) * asin(2q-1).
+func (t *TDigest) qLimit(q float64) float64 {
+	k := t.compression / (2 * math.Pi) * math.Asin(2*q-1)
+	k++
+
+	if k >= t.compression/4 {
+		return 1
+	}
+
+	return (math.Sin(k*2*math.Pi/t.compression) + 1) / 2
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+var tdigestQuantiles = []float64{0.001, 0.01, 0.1, 0.25, 0.5, 0.75, 0.9, 0.99, 0.999}
+
+// tdigestBound is the documented rank error bound of
+// a digest with the default compression at q.
+func tdigestBound(q float64) float64 {
+	if q <= 0.01 || q >= 0.99 {
+		return 0.0015
+	}
+
+	return 0.0025
+}
+
+// tdigestData will return n values drawn from the named distribution.
+func tdigestData(r *rand.Rand, dist string, n int) SliceFloat64 {
+	data := make(SliceFloat64, n)
+	for i := range data {
+		switch dist {
+		case "exponential":
+			data[i] = r.ExpFloat64()
+		case "normal":
+			data[i] = r.NormFloat64()
+		default:
+			data[i] = r.Float64()
+		}
+	}
+
+	return data
+}
+
+// buildTDigest will add the data to parts digests
+// and return them merged into one.
+func buildTDigest(data SliceFloat64, parts int) *TDigest {
+	merged := NewTDigest(DefaultTDigestCompression)
+	for p := 0; p < parts; p++ {
+		part := NewTDigest(DefaultTDigestCompression)
+		part.AddSlice(data[p*len(data)/parts : (p+1)*len(data)/parts])
+		merged.Merge(part)
+	}
+
+	return merged
+}
+
+func TestTDigestAccuracy(t *testing.T) {
+	const n = 200000
+	r := rand.New(rand.NewSource(1))
+
+	for _, dist := range []string{"exponential", "normal", "uniform"} {
+		data := tdigestData(r, dist, n)
+		sorted := data.Sorted()
+
+		for _, parts := range []int{1, 3} {
+			t.Run(fmt.Sprintf("%s/%d", dist, parts), func(t *testing.T) {
+				d := buildTDigest(data, parts)
+				if d.Count() != n || d.Min() != sorted[0] || d.Max() != sorted[n-1] {
+					t.Fatalf("Count, Min, Max = %d, %v, %v, want %d, %v, %v",
+						d.Count(), d.Min(), d.Max(), n, sorted[0], sorted[n-1])
+				}
+
+				for _, q := range tdigestQuantiles {
+					exact := sorted.Quantile(q, QuantileLinear)
+
+					// the rank of the estimate among the exact values.
+					estimate := d.Quantile(q)
+					rank := float64(sort.SearchFloat64s(sorted, estimate)) / n
+					if err := math.Abs(rank - q); err > tdigestBound(q) {
+						t.Errorf("Quantile(%v) = %v has rank %v, exact is %v", q, estimate, rank, exact)
+					}
+
+					if err := math.Abs(d.CDF(exact) - q); err > tdigestBound(q) {
+						t.Errorf("CDF(%v) = %v, want %v", exact, d.CDF(exact), q)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestTDigestBinary(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	d := buildTDigest(tdigestData(r, "exponential", 10000), 2)
+
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded TDigest
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Count() != d.Count() || decoded.Min() != d.Min() || decoded.Max() != d.Max() {
+		t.Errorf("decoded Count, Min, Max = %d, %v, %v, want %d, %v, %v",
+			decoded.Count(), decoded.Min(), decoded.Max(), d.Count(), d.Min(), d.Max())
+	}
+	for _, q := range tdigestQuantiles {
+		if got, want := decoded.Quantile(q), d.Quantile(q); got != want {
+			t.Errorf("decoded Quantile(%v) = %v, want %v", q, got, want)
+		}
+	}
+
+	for _, bad := range [][]byte{nil, data[:len(data)-1], append([]byte{0}, data[1:]...)} {
+		if err := decoded.UnmarshalBinary(bad); err != ErrTDigestEncoding {
+			t.Errorf("UnmarshalBinary of a bad encoding returned %v, want ErrTDigestEncoding", err)
+		}
+	}
+}
+
+func TestTDigestZeroValue(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	data := tdigestData(r, "exponential", 100000)
+	sorted := data.Sorted()
+
+	var d TDigest
+	if !math.IsNaN(d.Quantile(0.5)) {
+		t.Errorf("Quantile(0.5) of the zero TDigest = %v, want NaN", d.Quantile(0.5))
+	}
+
+	empty, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded TDigest
+	if err := decoded.UnmarshalBinary(empty); err != nil || decoded.Count() != 0 {
+		t.Errorf("decoding the zero TDigest = %d, %v, want 0, nil", decoded.Count(), err)
+	}
+
+	d.AddSlice(data)
+	for _, q := range tdigestQuantiles {
+		rank := float64(sort.SearchFloat64s(sorted, d.Quantile(q))) / float64(len(data))
+		if err := math.Abs(rank - q); err > tdigestBound(q) {
+			t.Errorf("Quantile(%v) of the zero TDigest has rank %v", q, rank)
+		}
+	}
+}
+
+func TestTDigestUnmarshalNaN(t *testing.T) {
+	d := NewTDigest(DefaultTDigestCompression)
+	d.AddSlice(SliceFloat64{1, 2, 3})
+	data, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the compression and the count are the first two
+	// 8 byte fields after the version byte.
+	for _, offset := range []int{1, 9} {
+		bad := append([]byte(nil), data...)
+		binary.BigEndian.PutUint64(bad[offset:], math.Float64bits(math.NaN()))
+
+		var decoded TDigest
+		if err := decoded.UnmarshalBinary(bad); err != ErrTDigestEncoding {
+			t.Errorf("UnmarshalBinary with NaN at offset %d returned %v, want ErrTDigestEncoding", offset, err)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"cmp"
+	"container/heap"
+	"math/bits"
+	"slices"
+	"sort"
+)
+
+// TopK will return the k largest values of the slice and their
+// indices, from the largest value to the smallest, in O(n log k).
+// Equal values are ordered by index. NaN values are ignored.
+func TopK[S ~[]E, E cmp.Ordered](s S, k int) (S, SliceInt) {
+	return selectK(s, k, func(a, b E) bool {
+		return a > b
+	})
+}
+
+// BottomK will return the k smallest values of the slice and their
+// indices, from the smallest value to the largest, in O(n log k).
+// Equal values are ordered by index. NaN values are ignored.
+func BottomK[S ~[]E, E cmp.Ordered](s S, k int) (S, SliceInt) {
+	return selectK(s, k, func(a, b E) bool {
+		return a < b
+	})
+}
+
+// NthElement will return the value that would be at index n if the
+// slice were sorted in ascending order, in O(n) on average, without
+// reordering the slice. NaN values are ignored, and ErrBounds is
+// returned if n is not the index of one of the remaining values.
+func NthElement[S ~[]E, E cmp.Ordered](s S, n int) (value E, err error) {
+	values := make(S, 0, len(s))
+	for _, v := range s {
+		if !isNaN(v) {
+			values = append(values, v)
+		}
+	}
+	if n < 0 || n >= len(values) {
+		return value, ErrBounds
+	}
+
+	return quickselect(values, n), nil
+}
+
+// TopKByValue will return the keys and values of the k largest
+// values of the map, from the largest value to the smallest.
+// Equal values are ordered by key.
+func TopKByValue[M ~map[K]V, K cmp.Ordered, V Number](m M, k int) ([]K, []V) {
+	if k > len(m) {
+		k = len(m)
+	}
+	if k <= 0 {
+		return []K{}, []V{}
+	}
+
+	type entry struct {
+		key   K
+		value V
+	}
+	better := func(a, b entry) bool {
+		return a.value > b.value || (a.value == b.value && a.key < b.key)
+	}
+
+	h := &kHeap[entry]{worse: func(a, b entry) bool {
+		return better(b, a)
+	}}
+	for key, value := range m {
+		h.offer(entry{key: key, value: value}, k)
+	}
+
+	sort.Slice(h.items, func(i, j int) bool {
+		return better(h.items[i], h.items[j])
+	})
+	keys, values := make([]K, k), make([]V, k)
+	for i, e := range h.items {
+		keys[i], values[i] = e.key, e.value
+	}
+
+	return keys, values
+}
+
+// TopK will return the k largest values of the slice and
+// their indices, from the largest value to the smallest.
+func (s SliceFloat64) TopK(k int) (SliceFloat64, SliceInt) {
+	return TopK(s, k)
+}
+
+// BottomK will return the k smallest values of the slice and
+// their indices, from the smallest value to the largest.
+func (s SliceFloat64) BottomK(k int) (SliceFloat64, SliceInt) {
+	return BottomK(s, k)
+}
+
+// NthElement will return the value that would be at index n
+// if the slice were sorted in ascending order.
+func (s SliceFloat64) NthElement(n int) (float64, error) {
+	return NthElement(s, n)
+}
+
+// TopK will return the k largest values of the slice and
+// their indices, from the largest value to the smallest.
+func (s SliceInt) TopK(k int) (SliceInt, SliceInt) {
+	return TopK(s, k)
+}
+
+// BottomK will return the k smallest values of the slice and
+// their indices, from the smallest value to the largest.
+func (s SliceInt) BottomK(k int) (SliceInt, SliceInt) {
+	return BottomK(s, k)
+}
+
+// NthElement will return the value that would be at index n
+// if the slice were sorted in ascending order.
+func (s SliceInt) NthElement(n int) (int, error) {
+	return NthElement(s, n)
+}
+
+// TopK will return the k largest values of the slice and
+// their indices, from the largest value to the smallest.
+func (s SliceInt64) TopK(k int) (SliceInt64, SliceInt) {
+	return TopK(s, k)
+}
+
+// BottomK will return the k smallest values of the slice and
+// their indices, from the smallest value to the largest.
+func (s SliceInt64) BottomK(k int) (SliceInt64, SliceInt) {
+	return BottomK(s, k)
+}
+
+// NthElement will return the value that would be at index n
+// if the slice were sorted in ascending order.
+func (s SliceInt64) NthElement(n int) (int64, error) {
+	return NthElement(s, n)
+}
+
+// TopKByValue will return the keys and counts of the k largest
+// counts, from the largest count to the smallest.
+func (m MapStringInt) TopKByValue(k int) (SliceString, SliceInt) {
+	return TopKByValue(m, k)
+}
+
+// TopKByValue will return the keys and values of the k largest
+// values, from the largest value to the smallest.
+func (m MapStringFloat64) TopKByValue(k int) (SliceString, SliceFloat64) {
+	return TopKByValue(m, k)
+}
+
+// TopKByValue will return the keys and values of the k largest
+// values, from the largest value to the smallest.
+func (m MapIntFloat64) TopKByValue(k int) (SliceInt, SliceFloat64) {
+	return TopKByValue(m, k)
+}
+
+// selectK will return the k values of the slice that come first by
+// the supplied ordering, and their indices, in that order.
+func selectK[S ~[]E, E cmp.Ordered](s S, k int, before func(a, b E) bool) (S, SliceInt) {
+	better := func(i, j int) bool {
+		return before(s[i], s[j]) || (s[i] == s[j] && i < j)
+	}
+
+	h := &kHeap[int]{worse: func(i, j int) bool {
+		return better(j, i)
+	}}
+	if k > 0 {
+		for i, v := range s {
+			if !isNaN(v) {
+				h.offer(i, k)
+			}
+		}
+	}
+
+	indices := SliceInt(h.items)
+	sort.Slice(indices, func(a, b int) bool {
+		return better(indices[a], indices[b])
+	})
+	values := make(S, len(indices))
+	for i, index := range indices {
+		values[i] = s[index]
+	}
+	if indices == nil {
+		indices = SliceInt{}
+	}
+
+	return values, indices
+}
+
+// kHeap is a heap of the best k items seen so far,
+// with the worst of them at the root.
+type kHeap[T any] struct {
+	items []T
+	worse func(a, b T) bool
+}
+
+// offer will add the item if there are fewer than
+// k items, or if it is better than the worst item.
+func (h *kHeap[T]) offer(item T, k int) {
+	if len(h.items) < k {
+		heap.Push(h, item)
+		return
+	}
+
+	if h.worse(h.items[0], item) {
+		h.items[0] = item
+		heap.Fix(h, 0)
+	}
+}
+
+func (h *kHeap[T]) Len() int {
+	return len(h.items)
+}
+
+func (h *kHeap[T]) Less(i, j int) bool {
+	return h.worse(h.items[i], h.items[j])
+}
+
+func (h *kHeap[T]) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+}
+
+func (h *kHeap[T]) Push(x interface{}) {
+	h.items = append(h.items, x.(T))
+}
+
+func (h *kHeap[T]) Pop() interface{} {
+	item := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return item
+}
+
+// quickselect will partially reorder s so that the value at index n
+// is the value a full sort would place there, and return it. It falls
+// back to sorting when partitioning stops making progress.
+func quickselect[S ~[]E, E cmp.Ordered](s S, n int) E {
+	lo, hi := 0, len(s)-1
+	for depth := 2 * bits.Len(uint(len(s))); lo < hi; depth-- {
+		if depth == 0 {
+			slices.Sort(s[lo : hi+1])
+			break
+		}
+
+		// median of three pivot, moved to the end.
+		mid := lo + (hi-lo)/2
+		if s[mid] < s[lo] {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+		if s[hi] < s[lo] {
+			s[hi], s[lo] = s[lo], s[hi]
+		}
+		if s[mid] < s[hi] {
+			s[mid], s[hi] = s[hi], s[mid]
+		}
+		pivot := s[hi]
+
+		// three-way partition: [lo,lt) < pivot, [lt,gt] == pivot, (gt,hi] > pivot.
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch {
+			case s[i] < pivot:
+				s[lt], s[i] = s[i], s[lt]
+				lt++
+				i++
+			case s[i] > pivot:
+				s[i], s[gt] = s[gt], s[i]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case n < lt:
+			hi = lt - 1
+		case n > gt:
+			lo = gt + 1
+		default:
+			return s[n]
+		}
+	}
+
+	return s[n]
+}
+
+// isNaN will check if a value is a floating point NaN,
+// the only value that is not equal to itself.
+func isNaN[E comparable](v E) bool {
+	return v != v
+}
@@ -0,0 +1,195 @@
+// Copyright 2020 Humility AI Incorporated, All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sam
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMaxNWithIndex(t *testing.T) {
+	s := SliceFloat64{5, 1, 4, 9, 2, 9, 7}
+
+	got := s.MaxNWithIndex(3)
+	want := MapIntFloat64{3: 9, 5: 9, 6: 7}
+	if len(got) != len(want) {
+		t.Fatalf("MaxNWithIndex(3) = %v, want %v", got, want)
+	}
+	for index, value := range want {
+		if v, ok := got[index]; !ok || v != value {
+			t.Fatalf("MaxNWithIndex(3) = %v, want %v", got, want)
+		}
+	}
+
+	if got := s.MaxNWithIndex(0); got == nil || len(got) != 0 {
+		t.Errorf("MaxNWithIndex(0) = %v, want an empty map", got)
+	}
+	if got := s.MaxNWithIndex(100); len(got) != len(s) {
+		t.Errorf("MaxNWithIndex(100) has %d values, want %d", len(got), len(s))
+	}
+}
+
+func TestTopK(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for trial := 0; trial < 1000; trial++ {
+		s := make(SliceFloat64, r.Intn(40))
+		for i := range s {
+			s[i] = float64(r.Intn(10))
+			if r.Intn(10) == 0 {
+				s[i] = math.NaN()
+			}
+		}
+		k := r.Intn(45) - 2
+
+		// the expected order: by value, then by index.
+		var indices SliceInt
+		for i, v := range s {
+			if !math.IsNaN(v) {
+				indices = append(indices, i)
+			}
+		}
+		want := k
+		if want < 0 {
+			want = 0
+		}
+		if want > len(indices) {
+			want = len(indices)
+		}
+
+		sort.SliceStable(indices, func(a, b int) bool {
+			return s[indices[a]] > s[indices[b]]
+		})
+		values, got := s.TopK(k)
+		if !got.EqualOrdered(indices[:want]) {
+			t.Fatalf("%v.TopK(%d) indices = %v, want %v", s, k, got, indices[:want])
+		}
+		for i, index := range got {
+			if values[i] != s[index] {
+				t.Fatalf("%v.TopK(%d) values = %v do not match indices %v", s, k, values, got)
+			}
+		}
+
+		sort.SliceStable(indices, func(a, b int) bool {
+			if s[indices[a]] != s[indices[b]] {
+				return s[indices[a]] < s[indices[b]]
+			}
+			return indices[a] < indices[b]
+		})
+		if _, got := s.BottomK(k); !got.EqualOrdered(indices[:want]) {
+			t.Fatalf("%v.BottomK(%d) indices = %v, want %v", s, k, got, indices[:want])
+		}
+
+		sorted := s.DropNaN().Sorted()
+		for n := -1; n <= len(sorted); n++ {
+			v, err := s.NthElement(n)
+			if n < 0 || n >= len(sorted) {
+				if err != ErrBounds {
+					t.Fatalf("%v.NthElement(%d) error = %v, want ErrBounds", s, n, err)
+				}
+				continue
+			}
+			if err != nil || v != sorted[n] {
+				t.Fatalf("%v.NthElement(%d) = %v, %v, want %v", s, n, v, err, sorted[n])
+			}
+		}
+	}
+}
+
+func TestTopKByValue(t *testing.T) {
+	m := MapStringInt{"a": 3, "b": 5, "c": 3, "d": 1}
+
+	keys, values := m.TopKByValue(3)
+	if !keys.EqualOrdered(SliceString{"b", "a", "c"}) || !values.EqualOrdered(SliceInt{5, 3, 3}) {
+		t.Errorf("TopKByValue(3) = %v, %v, want [b a c], [5 3 3]", keys, values)
+	}
+
+	maxN := MapIntFloat64{1: 1, 2: 2, 3: 3}.MaxN(2)
+	if len(maxN) != 2 || maxN[2] != 2 || maxN[3] != 3 {
+		t.Errorf("MaxN(2) = %v, want map[2:2 3:3]", maxN)
+	}
+}
+
+// rescanMaxN is the previous MaxN, which rescans its
+// buffer for the smallest value on every element.
+func rescanMaxN(s SliceFloat64, n int) SliceFloat64 {
+	var maxN SliceFloat64
+	for _, value := range s {
+		if len(maxN) < n {
+			maxN = append(maxN, value)
+		} else {
+			minIndex, minValue := maxN.MinIndex()
+			if value > minValue {
+				maxN[minIndex] = value
+			}
+		}
+	}
+
+	return maxN
+}
+
+// benchmarkData will return n random values.
+func benchmarkData(n int) SliceFloat64 {
+	r := rand.New(rand.NewSource(1))
+	s := make(SliceFloat64, n)
+	for i := range s {
+		s[i] = r.Float64()
+	}
+
+	return s
+}
+
+func BenchmarkTopK(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		s := benchmarkData(n)
+		for _, k := range []int{10, 100, 1000} {
+			if k >= n {
+				continue
+			}
+
+			b.Run(fmt.Sprintf("heap/n=%d/k=%d", n, k), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					s.TopK(k)
+				}
+			})
+			b.Run(fmt.Sprintf("rescan/n=%d/k=%d", n, k), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					rescanMaxN(s, k)
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkNthElement(b *testing.B) {
+	for _, n := range []int{1000, 100000} {
+		s := benchmarkData(n)
+		for _, k := range []int{10, n / 2} {
+			b.Run(fmt.Sprintf("quickselect/n=%d/k=%d", n, k), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					s.NthElement(k)
+				}
+			})
+			b.Run(fmt.Sprintf("sort/n=%d/k=%d", n, k), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					_ = s.Sorted()[k]
+				}
+			})
+		}
+	}
+}